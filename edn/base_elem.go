@@ -14,7 +14,10 @@
 
 package edn
 
-import "strings"
+import (
+	"io"
+	"strings"
+)
 
 // baseElement defines the base element features.
 type baseElemImpl struct {
@@ -25,6 +28,9 @@ type baseElemImpl struct {
 	// stringer is the mechanism to serialize this element into EDN or JSON or whatever format.
 	stringer stringerFunc
 
+	// writer is the optional mechanism to stream this element, if not set the stringer output is written instead.
+	writer writerFunc
+
 	// equality is the tester for equality
 	equality equalityFunc
 
@@ -70,6 +76,20 @@ func (elem *baseElemImpl) Serialize(serializer Serializer) (composition string,
 	return elem.stringer(serializer, elem.Tag(), elem.Value())
 }
 
+// SerializeTo writes the serialized element onto the writer or returns the appropriate error.
+func (elem *baseElemImpl) SerializeTo(writer io.Writer, serializer Serializer) (err error) {
+	if elem.writer != nil {
+		err = elem.writer(writer, serializer, elem.Tag(), elem.Value())
+	} else {
+		var composition string
+		if composition, err = elem.stringer(serializer, elem.Tag(), elem.Value()); err == nil {
+			_, err = io.WriteString(writer, composition)
+		}
+	}
+
+	return err
+}
+
 // HasTag returns true if the element has a tag prefix
 func (elem *baseElemImpl) HasTag() bool {
 	return len(elem.tag) != 0
@@ -0,0 +1,236 @@
+// Copyright 2018-2019 Workiva Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package edn
+
+import (
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+const (
+
+	// BigDecSuffix marks a number literal as an arbitrary precision decimal.
+	BigDecSuffix = "M"
+)
+
+// Decimal is an exact decimal number, the value is unscaled × 10^-scale in the same way as java.math.BigDecimal. The
+// scale is kept so that 1.50M is written back as 1.50M. The zero value is 0.
+type Decimal struct {
+	unscaled *big.Int
+	scale    int32
+}
+
+// NewDecimal creates the decimal unscaled × 10^-scale. A nil unscaled value is 0.
+func NewDecimal(unscaled *big.Int, scale int32) *Decimal {
+	value := new(big.Int)
+	if unscaled != nil {
+		value.Set(unscaled)
+	}
+
+	return &Decimal{
+		unscaled: value,
+		scale:    scale,
+	}
+}
+
+// ParseDecimal parses the decimal from the string, e.g. -12.340 or 1.5E-3. The M suffix is optional.
+func ParseDecimal(str string) (dec *Decimal, err error) {
+
+	value := strings.TrimSuffix(str, BigDecSuffix)
+
+	var exponent int64
+	if index := strings.IndexAny(value, "eE"); index != -1 {
+		if exponent, err = strconv.ParseInt(value[index+1:], 10, 32); err != nil {
+			return nil, MakeErrorWithFormat(ErrInvalidInput, "decimal: %s", str)
+		}
+		value = value[:index]
+	}
+
+	scale := int64(0)
+	if index := strings.IndexByte(value, '.'); index != -1 {
+		scale = int64(len(value) - index - 1)
+		value = value[:index] + value[index+1:]
+	}
+
+	unscaled, ok := new(big.Int).SetString(value, 10)
+	if scale -= exponent; !ok || scale != int64(int32(scale)) {
+		return nil, MakeErrorWithFormat(ErrInvalidInput, "decimal: %s", str)
+	}
+
+	return &Decimal{
+		unscaled: unscaled,
+		scale:    int32(scale),
+	}, nil
+}
+
+// bigZero is the unscaled value of the zero Decimal, it must not be changed.
+var bigZero = new(big.Int)
+
+// value returns the unscaled value, which is nil in the zero Decimal.
+func (dec *Decimal) value() *big.Int {
+	if dec.unscaled == nil {
+		return bigZero
+	}
+	return dec.unscaled
+}
+
+// Unscaled returns a copy of the unscaled value.
+func (dec *Decimal) Unscaled() *big.Int {
+	return new(big.Int).Set(dec.value())
+}
+
+// Scale returns the number of digits after the decimal point.
+func (dec *Decimal) Scale() int32 {
+	return dec.scale
+}
+
+// rescaled returns the unscaled value of the decimal at the larger scale.
+func (dec *Decimal) rescaled(scale int32) *big.Int {
+	if scale <= dec.scale {
+		return dec.value()
+	}
+
+	factor := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(scale-dec.scale)), nil)
+	return factor.Mul(factor, dec.value())
+}
+
+// normalized returns the decimal without trailing zeros, so that equal decimals such as 1.0M and 1.00M have the same
+// unscaled value and scale. A negative scale is brought back to 0.
+func (dec *Decimal) normalized() *Decimal {
+	unscaled := dec.rescaled(0)
+	scale := dec.scale
+	if scale < 0 {
+		scale = 0
+	}
+
+	ten := big.NewInt(10)
+	quo, rem := new(big.Int), new(big.Int)
+	for ; scale > 0; scale-- {
+		if quo.QuoRem(unscaled, ten, rem); rem.Sign() != 0 {
+			break
+		}
+		unscaled = new(big.Int).Set(quo)
+	}
+
+	return NewDecimal(unscaled, scale)
+}
+
+// Cmp compares the values of the decimals, ignoring the scale: -1 if dec < other, 0 if they are equal and 1 if
+// dec > other.
+func (dec *Decimal) Cmp(other *Decimal) int {
+	scale := dec.scale
+	if other.scale > scale {
+		scale = other.scale
+	}
+	return dec.rescaled(scale).Cmp(other.rescaled(scale))
+}
+
+// Float64 returns the nearest float64 value.
+func (dec *Decimal) Float64() float64 {
+	f, _ := strconv.ParseFloat(dec.String(), 64)
+	return f
+}
+
+// String returns the decimal in plain notation, a negative scale is written as an exponent.
+func (dec *Decimal) String() string {
+
+	digits := new(big.Int).Abs(dec.value()).String()
+	sign := ""
+	if dec.value().Sign() < 0 {
+		sign = "-"
+	}
+
+	switch {
+	case dec.scale < 0:
+		digits += "E" + strconv.Itoa(int(-dec.scale))
+	case dec.scale > 0:
+		if pad := int(dec.scale) - len(digits) + 1; pad > 0 {
+			digits = strings.Repeat("0", pad) + digits
+		}
+		point := len(digits) - int(dec.scale)
+		digits = digits[:point] + "." + digits[point:]
+	}
+
+	return sign + digits
+}
+
+// init will add the element factory to the collection of factories
+func initBigDec(_ Lexer) error {
+
+	// The literals are read by the float pattern, which hands the M suffixed values to parseBigDec.
+	return addElementTypeFactory(BigDecType, func(input interface{}) (elem Element, e error) {
+		if v, ok := input.(*Decimal); ok {
+			elem, e = NewBigDecElement(v)
+		} else {
+			e = MakeError(ErrInvalidInput, input)
+		}
+		return elem, e
+	})
+}
+
+// parseBigDec parses the number literal into a decimal element.
+func parseBigDec(tokenValue string) (elem Element, err error) {
+	var dec *Decimal
+	if dec, err = ParseDecimal(tokenValue); err == nil {
+		elem, err = NewBigDecElement(dec)
+	}
+	return elem, err
+}
+
+// NewBigDecElement creates a new arbitrary precision decimal element. The value is copied so later changes to the
+// input do not affect the element, a nil value is an error.
+func NewBigDecElement(value *Decimal) (elem Element, err error) {
+
+	if value == nil {
+		return nil, MakeError(ErrInvalidInput, "nil decimal")
+	}
+
+	var base *baseElemImpl
+	if base, err = baseFactory().make(NewDecimal(value.unscaled, value.scale), BigDecType, func(serializer Serializer, tag string, value interface{}) (out string, e error) {
+		switch serializer.MimeType() {
+		case EvaEdnMimeType:
+			dec := value.(*Decimal)
+			var canonical bool
+			if canonical, e = isCanonical(serializer); e == nil {
+				if canonical {
+					dec = dec.normalized()
+				}
+				if len(tag) > 0 {
+					out = TagPrefix + tag + " "
+				}
+				out += dec.String() + BigDecSuffix
+			}
+		case JSONMimeType:
+			out = jsonTagged(tag, value.(*Decimal).String())
+		case TransitMimeType:
+			out, e = transitString(tag, value)
+		default:
+			e = MakeError(ErrUnknownMimeType, serializer.MimeType())
+		}
+		return out, e
+	}); err != nil {
+		panic(err)
+	}
+
+	// decimals are equal when their values are equal, 1.0M is the same as 1.00M.
+	base.equality = func(left, right Element) bool {
+		l, isLeft := left.Value().(*Decimal)
+		r, isRight := right.Value().(*Decimal)
+		return isLeft && isRight && l.Cmp(r) == 0
+	}
+
+	return base, nil
+}
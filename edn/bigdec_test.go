@@ -0,0 +1,274 @@
+// Copyright 2018-2019 Workiva Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package edn
+
+import (
+	"math/big"
+
+	"github.com/Workiva/eva-client-go/test"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// bigDecElement creates the decimal element, failing the test on an error.
+func bigDecElement(value *Decimal) Element {
+	elem, err := NewBigDecElement(value)
+	Ω(err).Should(BeNil())
+	return elem
+}
+
+var _ = Describe("BigDec in EDN", func() {
+
+	money, _ := ParseDecimal("12345678901234567890.10")
+
+	Context("Decimal", func() {
+
+		It("should parse and format decimals", func() {
+			tests := map[string]string{
+				"0":         "0",
+				"-0.00":     "0.00",
+				"1.50":      "1.50",
+				"-0.05":     "-0.05",
+				"+12.340":   "12.340",
+				"1.5E-3":    "0.0015",
+				"15E+2":     "15E2",
+				"1.5e3":     "15E2",
+				"1234.5678": "1234.5678",
+			}
+
+			for input, expected := range tests {
+				dec, err := ParseDecimal(input)
+				Ω(err).Should(BeNil(), input)
+				Ω(dec.String()).Should(BeEquivalentTo(expected), input)
+			}
+		})
+
+		It("should not parse bad decimals", func() {
+			for _, input := range []string{"", "1.2.3", "abc", "1E", "1Ex"} {
+				_, err := ParseDecimal(input)
+				Ω(err).Should(test.HaveMessage(ErrInvalidInput), input)
+			}
+		})
+
+		It("should compare the values", func() {
+			one := NewDecimal(big.NewInt(10), 1)
+			Ω(one.Cmp(NewDecimal(big.NewInt(100), 2))).Should(BeZero())
+			Ω(one.Cmp(NewDecimal(big.NewInt(11), 1))).Should(BeEquivalentTo(-1))
+			Ω(one.Cmp(NewDecimal(big.NewInt(1), 1))).Should(BeEquivalentTo(1))
+			Ω(one.Unscaled().Int64()).Should(BeEquivalentTo(10))
+			Ω(one.Scale()).Should(BeEquivalentTo(1))
+			Ω(one.Float64()).Should(BeEquivalentTo(1.0))
+		})
+
+		It("should treat the zero value as 0", func() {
+			var zero Decimal
+			Ω(zero.String()).Should(BeEquivalentTo("0"))
+			Ω(zero.Cmp(NewDecimal(big.NewInt(0), 2))).Should(BeZero())
+			Ω(zero.Cmp(NewDecimal(big.NewInt(1), 2))).Should(BeEquivalentTo(-1))
+			Ω(NewDecimal(big.NewInt(-1), 0).Cmp(&zero)).Should(BeEquivalentTo(-1))
+			Ω(zero.Unscaled().Sign()).Should(BeZero())
+			Ω(zero.Float64()).Should(BeZero())
+			Ω(NewDecimal(nil, 1).String()).Should(BeEquivalentTo("0.0"))
+			Ω(bigDecElement(&zero).String()).Should(BeEquivalentTo("0M"))
+		})
+	})
+
+	Context("", func() {
+
+		It("should initialize without issue", func() {
+			lexer, err := newLexer()
+			Ω(err).Should(BeNil())
+
+			delete(typeFactories, BigDecType)
+			err = initBigDec(lexer)
+			Ω(err).Should(BeNil())
+			_, has := typeFactories[BigDecType]
+			Ω(has).Should(BeTrue())
+
+			err = initBigDec(lexer)
+			Ω(err).Should(test.HaveMessage(ErrInvalidFactory))
+		})
+
+		It("should create elements from the factory", func() {
+			elem, err := typeFactories[BigDecType](money)
+			Ω(err).Should(BeNil())
+			Ω(elem.ElementType()).Should(BeEquivalentTo(BigDecType))
+			Ω(elem.Value().(*Decimal).Cmp(money)).Should(BeZero())
+		})
+
+		It("should not create elements from the factory if the input is not a the right type", func() {
+			elem, err := typeFactories[BigDecType](1.5)
+			Ω(err).Should(test.HaveMessage(ErrInvalidInput))
+			Ω(elem).Should(BeNil())
+		})
+
+		It("should not be created from nil", func() {
+			elem, err := NewBigDecElement(nil)
+			Ω(err).Should(test.HaveMessage(ErrInvalidInput))
+			Ω(elem).Should(BeNil())
+
+			elem, err = typeFactories[BigDecType]((*Decimal)(nil))
+			Ω(err).Should(test.HaveMessage(ErrInvalidInput))
+			Ω(elem).Should(BeNil())
+		})
+
+		It("should be created from a primitive", func() {
+			elem, err := NewPrimitiveElement(money)
+			Ω(err).Should(BeNil())
+			Ω(elem.ElementType()).Should(BeEquivalentTo(BigDecType))
+		})
+
+		It("should panic if the base factory errors.", func() {
+			origFac := baseFactory
+			baseFactory = func() elementFactory { return &breakerFactory{} }
+
+			wrapper := func() {
+				NewBigDecElement(money)
+			}
+
+			Ω(wrapper).Should(Panic())
+			baseFactory = origFac
+		})
+	})
+
+	Context("with the default marshaller", func() {
+
+		It("should serialize with the suffix", func() {
+			edn, err := bigDecElement(money).Serialize(EvaEdnMimeType)
+			Ω(err).Should(BeNil())
+			Ω(edn).Should(BeEquivalentTo("12345678901234567890.10M"))
+		})
+
+		It("should not serialize to an unknown mime type", func() {
+			_, err := bigDecElement(money).Serialize(SerializerMimeType("InvalidType"))
+			Ω(err).Should(test.HaveMessage(ErrUnknownMimeType))
+		})
+
+		It("should be distinct from floats", func() {
+			elem, err := Parse("1.5M")
+			Ω(err).Should(BeNil())
+			Ω(elem.Equals(NewFloatElement(1.5))).Should(BeFalse())
+
+			other, err := Parse("1.50M")
+			Ω(err).Should(BeNil())
+			Ω(elem.Equals(other)).Should(BeTrue())
+		})
+
+		It("should serialize canonically without trailing zeros", func() {
+			for literal, expected := range map[string]string{
+				"1.00M":     "1M",
+				"12.3400M":  "12.34M",
+				"-2.50M":    "-2.5M",
+				"0.000M":    "0M",
+				"1E2M":      "100M",
+				"0.0012M":   "0.0012M",
+				"#foo 1.0M": "#foo 1M",
+			} {
+				elem, err := Parse(literal)
+				Ω(err).Should(BeNil())
+				str, err := elem.Serialize(EvaEdnMimeType + ";canonical=true")
+				Ω(err).Should(BeNil())
+				Ω(str).Should(BeEquivalentTo(expected), literal)
+			}
+		})
+
+		It("should be the same key in maps and sets at any scale", func() {
+			for _, str := range []string{"#{1.0M 1.00M}", "{1.0M :a 1.00M :b}"} {
+				_, err := Parse(str)
+				Ω(err).Should(test.HaveMessage(ErrDuplicateKey))
+			}
+
+			first, err := Parse("{1.0M :a}")
+			Ω(err).Should(BeNil())
+			second, err := Parse("{1.00M :a}")
+			Ω(err).Should(BeNil())
+			Ω(first.Equals(second)).Should(BeTrue())
+
+			key, err := Parse("1.000M")
+			Ω(err).Should(BeNil())
+			value, err := first.(CollectionElement).Get(key)
+			Ω(err).Should(BeNil())
+			Ω(value.String()).Should(BeEquivalentTo(":a"))
+
+			set, err := Parse("#{1.5M 2M}")
+			Ω(err).Should(BeNil())
+			other, err := Parse("#{2.00M 1.50M}")
+			Ω(err).Should(BeNil())
+			Ω(set.Equals(other)).Should(BeTrue())
+		})
+
+		It("should round trip through transit", func() {
+			elem := bigDecElement(money)
+
+			str, err := elem.Serialize(TransitMimeType)
+			Ω(err).Should(BeNil())
+			Ω(str).Should(BeEquivalentTo(`["~#'","~f12345678901234567890.10"]`))
+
+			parsed, err := ParseTransit(str)
+			Ω(err).Should(BeNil())
+			Ω(parsed.Equals(elem)).Should(BeTrue())
+
+			str, err = elem.Serialize(JSONMimeType)
+			Ω(err).Should(BeNil())
+			Ω(str).Should(BeEquivalentTo("12345678901234567890.10"))
+		})
+
+		It("should marshal and unmarshal decimals", func() {
+			data, err := Marshal(struct {
+				Price *Decimal `edn:"price"`
+			}{money})
+			Ω(err).Should(BeNil())
+			Ω(string(data)).Should(BeEquivalentTo("{:price 12345678901234567890.10M}"))
+
+			var out struct {
+				Price Decimal `edn:"price"`
+				Total float64 `edn:"total"`
+			}
+			Ω(Unmarshal([]byte("{:price 0.10M :total 2.5M}"), &out)).Should(BeNil())
+			Ω(out.Price.String()).Should(BeEquivalentTo("0.10"))
+			Ω(out.Total).Should(BeEquivalentTo(2.5))
+		})
+	})
+
+	Context("Parsing", func() {
+
+		tests := map[string]string{
+			"0M":                        "0",
+			"+0M":                       "0",
+			"-0M":                       "0",
+			"1M":                        "1",
+			"-1M":                       "-1",
+			"1.50M":                     "1.50",
+			"12345678901234567890.10M":  "12345678901234567890.10",
+			"-0.000000000000000000001M": "-0.000000000000000000001",
+			"1.5E-3M":                   "0.0015",
+			"#my/tag 1.0M":              "1.0",
+		}
+
+		for expression, expected := range tests {
+			expression, expected := expression, expected
+			It("should parse the expression: `"+expression+"`", func() {
+				elem, err := Parse(expression)
+				Ω(err).Should(BeNil())
+				Ω(elem.ElementType()).Should(BeEquivalentTo(BigDecType))
+				Ω(elem.Value().(*Decimal).String()).Should(BeEquivalentTo(expected))
+
+				str, err := elem.Serialize(EvaEdnMimeType)
+				Ω(err).Should(BeNil())
+				Ω(str).Should(HaveSuffix(expected + BigDecSuffix))
+			})
+		}
+	})
+})
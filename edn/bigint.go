@@ -0,0 +1,88 @@
+// Copyright 2018-2019 Workiva Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package edn
+
+import (
+	"math/big"
+	"strings"
+)
+
+const (
+
+	// BigIntSuffix marks an integer literal as arbitrary precision.
+	BigIntSuffix = "N"
+)
+
+// init will add the element factory to the collection of factories
+func initBigInt(_ Lexer) error {
+
+	// The literals are read by the integer pattern, which hands the N suffixed and overflowing values to parseBigInt.
+	return addElementTypeFactory(BigIntType, func(input interface{}) (elem Element, e error) {
+		if v, ok := input.(*big.Int); ok {
+			elem, e = NewBigIntElement(v)
+		} else {
+			e = MakeError(ErrInvalidInput, input)
+		}
+		return elem, e
+	})
+}
+
+// parseBigInt parses the integer literal, with or without the N suffix, into a big integer element.
+func parseBigInt(tokenValue string) (elem Element, err error) {
+	if v, ok := new(big.Int).SetString(strings.TrimSuffix(tokenValue, BigIntSuffix), 10); ok {
+		elem, err = NewBigIntElement(v)
+	} else {
+		err = MakeError(ErrInvalidInput, tokenValue)
+	}
+	return elem, err
+}
+
+// NewBigIntElement creates a new arbitrary precision integer element. The value is copied so later changes to the
+// input do not affect the element, a nil value is an error.
+func NewBigIntElement(value *big.Int) (elem Element, err error) {
+
+	if value == nil {
+		return nil, MakeError(ErrInvalidInput, "nil big integer")
+	}
+
+	var base *baseElemImpl
+	if base, err = baseFactory().make(new(big.Int).Set(value), BigIntType, func(serializer Serializer, tag string, value interface{}) (out string, e error) {
+		switch serializer.MimeType() {
+		case EvaEdnMimeType:
+			if len(tag) > 0 {
+				out = TagPrefix + tag + " "
+			}
+			out += value.(*big.Int).String() + BigIntSuffix
+		case JSONMimeType:
+			out = jsonTagged(tag, value.(*big.Int).String())
+		case TransitMimeType:
+			out, e = transitString(tag, value)
+		default:
+			e = MakeError(ErrUnknownMimeType, serializer.MimeType())
+		}
+		return out, e
+	}); err != nil {
+		panic(err)
+	}
+
+	// pointers to equal big integers are not deeply equal, so compare the values.
+	base.equality = func(left, right Element) bool {
+		l, isLeft := left.Value().(*big.Int)
+		r, isRight := right.Value().(*big.Int)
+		return isLeft && isRight && l.Cmp(r) == 0
+	}
+
+	return base, nil
+}
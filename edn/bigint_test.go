@@ -0,0 +1,193 @@
+// Copyright 2018-2019 Workiva Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package edn
+
+import (
+	"math/big"
+
+	"github.com/Workiva/eva-client-go/test"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// bigIntElement creates the big integer element, failing the test on an error.
+func bigIntElement(value *big.Int) Element {
+	elem, err := NewBigIntElement(value)
+	Ω(err).Should(BeNil())
+	return elem
+}
+
+var _ = Describe("BigInt in EDN", func() {
+
+	large, _ := new(big.Int).SetString("12345678901234567890", 10)
+
+	Context("", func() {
+
+		It("should initialize without issue", func() {
+			lexer, err := newLexer()
+			Ω(err).Should(BeNil())
+
+			delete(typeFactories, BigIntType)
+			err = initBigInt(lexer)
+			Ω(err).Should(BeNil())
+			_, has := typeFactories[BigIntType]
+			Ω(has).Should(BeTrue())
+
+			err = initBigInt(lexer)
+			Ω(err).Should(test.HaveMessage(ErrInvalidFactory))
+		})
+
+		It("should create elements from the factory", func() {
+			elem, err := typeFactories[BigIntType](large)
+			Ω(err).Should(BeNil())
+			Ω(elem.ElementType()).Should(BeEquivalentTo(BigIntType))
+			Ω(elem.Value().(*big.Int).Cmp(large)).Should(BeZero())
+		})
+
+		It("should not create elements from the factory if the input is not a the right type", func() {
+			elem, err := typeFactories[BigIntType](int64(1))
+			Ω(err).Should(test.HaveMessage(ErrInvalidInput))
+			Ω(elem).Should(BeNil())
+		})
+
+		It("should not be created from nil", func() {
+			elem, err := NewBigIntElement(nil)
+			Ω(err).Should(test.HaveMessage(ErrInvalidInput))
+			Ω(elem).Should(BeNil())
+
+			elem, err = typeFactories[BigIntType]((*big.Int)(nil))
+			Ω(err).Should(test.HaveMessage(ErrInvalidInput))
+			Ω(elem).Should(BeNil())
+		})
+
+		It("should be created from a primitive", func() {
+			elem, err := NewPrimitiveElement(large)
+			Ω(err).Should(BeNil())
+			Ω(elem.ElementType()).Should(BeEquivalentTo(BigIntType))
+		})
+
+		It("should copy the value", func() {
+			value := big.NewInt(5)
+			elem := bigIntElement(value)
+			value.SetInt64(6)
+			Ω(elem.Value().(*big.Int).Int64()).Should(BeEquivalentTo(5))
+		})
+
+		It("should panic if the base factory errors.", func() {
+			origFac := baseFactory
+			baseFactory = func() elementFactory { return &breakerFactory{} }
+
+			wrapper := func() {
+				NewBigIntElement(large)
+			}
+
+			Ω(wrapper).Should(Panic())
+			baseFactory = origFac
+		})
+	})
+
+	Context("with the default marshaller", func() {
+
+		It("should serialize with the suffix", func() {
+			edn, err := bigIntElement(large).Serialize(EvaEdnMimeType)
+			Ω(err).Should(BeNil())
+			Ω(edn).Should(BeEquivalentTo("12345678901234567890N"))
+		})
+
+		It("should not serialize to an unknown mime type", func() {
+			_, err := bigIntElement(large).Serialize(SerializerMimeType("InvalidType"))
+			Ω(err).Should(test.HaveMessage(ErrUnknownMimeType))
+		})
+
+		It("should compare the values", func() {
+			Ω(bigIntElement(big.NewInt(1)).Equals(bigIntElement(big.NewInt(1)))).Should(BeTrue())
+			Ω(bigIntElement(big.NewInt(1)).Equals(bigIntElement(big.NewInt(2)))).Should(BeFalse())
+			Ω(bigIntElement(big.NewInt(1)).Equals(NewIntegerElement(1))).Should(BeFalse())
+		})
+
+		It("should round trip through json and transit", func() {
+			elem := bigIntElement(large)
+
+			str, err := elem.Serialize(JSONMimeType)
+			Ω(err).Should(BeNil())
+			Ω(str).Should(BeEquivalentTo("12345678901234567890"))
+
+			parsed, err := ParseJSON(str)
+			Ω(err).Should(BeNil())
+			Ω(parsed.Equals(elem)).Should(BeTrue())
+
+			str, err = elem.Serialize(TransitMimeType)
+			Ω(err).Should(BeNil())
+			Ω(str).Should(BeEquivalentTo(`["~#'","~n12345678901234567890"]`))
+
+			parsed, err = ParseTransit(str)
+			Ω(err).Should(BeNil())
+			Ω(parsed.Equals(elem)).Should(BeTrue())
+		})
+
+		It("should marshal and unmarshal big integers", func() {
+			data, err := Marshal(struct {
+				Value *big.Int `edn:"value"`
+			}{large})
+			Ω(err).Should(BeNil())
+			Ω(string(data)).Should(BeEquivalentTo("{:value 12345678901234567890N}"))
+
+			var out struct {
+				Value *big.Int `edn:"value"`
+				Small big.Int  `edn:"small"`
+			}
+			Ω(Unmarshal([]byte("{:value 12345678901234567890N :small 3}"), &out)).Should(BeNil())
+			Ω(out.Value.Cmp(large)).Should(BeZero())
+			Ω(out.Small.Int64()).Should(BeEquivalentTo(3))
+
+			var small int64
+			Ω(Unmarshal([]byte("7N"), &small)).Should(BeNil())
+			Ω(small).Should(BeEquivalentTo(7))
+			Ω(Unmarshal([]byte("12345678901234567890N"), &small)).Should(test.HaveMessage(ErrUnmarshal))
+		})
+	})
+
+	Context("Parsing", func() {
+
+		tests := map[string]string{
+			"0N":                     "0",
+			"+0N":                    "0",
+			"-0N":                    "0",
+			"1N":                     "1",
+			"-1N":                    "-1",
+			"1234N":                  "1234",
+			"12345678901234567890N":  "12345678901234567890",
+			"-12345678901234567890N": "-12345678901234567890",
+			"12345678901234567890":   "12345678901234567890",
+			"#my/tag 1N":             "1",
+		}
+
+		for expression, expected := range tests {
+			expression, expected := expression, expected
+			It("should parse the expression: `"+expression+"`", func() {
+				elem, err := Parse(expression)
+				Ω(err).Should(BeNil())
+				Ω(elem.ElementType()).Should(BeEquivalentTo(BigIntType))
+				Ω(elem.Value().(*big.Int).String()).Should(BeEquivalentTo(expected))
+			})
+		}
+
+		It("should keep the tag", func() {
+			elem, err := Parse("#my/tag 1N")
+			Ω(err).Should(BeNil())
+			Ω(elem.Tag()).Should(BeEquivalentTo("my/tag"))
+		})
+	})
+})
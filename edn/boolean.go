@@ -51,6 +51,10 @@ func NewBooleanElement(value bool) (elem Element) {
 				out = TagPrefix + tag + " "
 			}
 			out += strconv.FormatBool(value.(bool))
+		case JSONMimeType:
+			out = jsonTagged(tag, strconv.FormatBool(value.(bool)))
+		case TransitMimeType:
+			out, e = transitString(tag, value)
 		default:
 			e = MakeError(ErrUnknownMimeType, serializer.MimeType())
 		}
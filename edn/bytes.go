@@ -0,0 +1,83 @@
+// Copyright 2018-2019 Workiva Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package edn
+
+import (
+	"bytes"
+	"encoding/base64"
+)
+
+const (
+
+	// BytesElementTag defines the bytes tag value, the bytes are written as a base64 string.
+	BytesElementTag = "bytes"
+)
+
+// bytesStringProcessor used the string processor but will decode the base64 bytes.
+func bytesStringProcessor(tokenValue string) (el Element, e error) {
+	var data []byte
+	if data, e = base64.StdEncoding.DecodeString(tokenValue); e == nil {
+		el = NewBytesElement(data)
+	}
+
+	return el, e
+}
+
+// init will add the element factory to the collection of factories
+func initBytes(_ Lexer) error {
+	return addElementTypeFactory(BytesType, func(input interface{}) (elem Element, e error) {
+		if v, ok := input.([]byte); ok {
+			elem = NewBytesElement(v)
+		} else {
+			e = MakeError(ErrInvalidInput, input)
+		}
+		return elem, e
+	})
+}
+
+// NewBytesElement creates a new bytes element. The value is copied so later changes to the input do not affect the
+// element.
+func NewBytesElement(value []byte) (elem Element) {
+
+	var base *baseElemImpl
+	var err error
+	if base, err = baseFactory().make(append([]byte{}, value...), BytesType, func(serializer Serializer, tag string, value interface{}) (out string, e error) {
+		switch serializer.MimeType() {
+		case EvaEdnMimeType:
+			if len(tag) > 0 {
+				out = TagPrefix + tag + " "
+			}
+			out += quoteString(base64.StdEncoding.EncodeToString(value.([]byte)))
+		case JSONMimeType:
+			out = jsonTagged(tag, jsonQuote(base64.StdEncoding.EncodeToString(value.([]byte))))
+		case TransitMimeType:
+			out, e = transitString(tag, value)
+		default:
+			e = MakeError(ErrUnknownMimeType, serializer.MimeType())
+		}
+		return out, e
+	}); err != nil {
+		panic(err)
+	}
+
+	base.equality = func(left, right Element) bool {
+		l, isLeft := left.Value().([]byte)
+		r, isRight := right.Value().([]byte)
+		return isLeft && isRight && bytes.Equal(l, r)
+	}
+
+	base.SetTag(BytesElementTag)
+	return base
+}
@@ -0,0 +1,153 @@
+// Copyright 2018-2019 Workiva Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package edn
+
+import (
+	"github.com/Workiva/eva-client-go/test"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Bytes in EDN", func() {
+
+	data := []byte{1, 2, 3, 250}
+
+	Context("", func() {
+
+		It("should initialize without issue", func() {
+			lexer, err := newLexer()
+			Ω(err).Should(BeNil())
+
+			delete(typeFactories, BytesType)
+			err = initBytes(lexer)
+			Ω(err).Should(BeNil())
+			_, has := typeFactories[BytesType]
+			Ω(has).Should(BeTrue())
+
+			err = initBytes(lexer)
+			Ω(err).Should(test.HaveMessage(ErrInvalidFactory))
+		})
+
+		It("should create elements from the factory", func() {
+			elem, err := typeFactories[BytesType](data)
+			Ω(err).Should(BeNil())
+			Ω(elem.ElementType()).Should(BeEquivalentTo(BytesType))
+			Ω(elem.Value()).Should(BeEquivalentTo(data))
+		})
+
+		It("should not create elements from the factory if the input is not a the right type", func() {
+			elem, err := typeFactories[BytesType]("AQID")
+			Ω(err).Should(test.HaveMessage(ErrInvalidInput))
+			Ω(elem).Should(BeNil())
+		})
+
+		It("should be created from a primitive", func() {
+			elem, err := NewPrimitiveElement(data)
+			Ω(err).Should(BeNil())
+			Ω(elem.ElementType()).Should(BeEquivalentTo(BytesType))
+			Ω(elem.Tag()).Should(BeEquivalentTo(BytesElementTag))
+		})
+
+		It("should copy the value", func() {
+			input := []byte{1, 2, 3}
+			elem := NewBytesElement(input)
+			input[0] = 9
+			Ω(elem.Value()).Should(BeEquivalentTo([]byte{1, 2, 3}))
+		})
+
+		It("should compare the contents", func() {
+			Ω(NewBytesElement(nil).Equals(NewBytesElement([]byte{}))).Should(BeTrue())
+			Ω(NewBytesElement(data).Equals(NewBytesElement([]byte{1, 2, 3}))).Should(BeFalse())
+			Ω(NewBytesElement(data).Equals(NewStringElement("AQID+g=="))).Should(BeFalse())
+		})
+
+		It("should panic if the base factory errors.", func() {
+			origFac := baseFactory
+			baseFactory = func() elementFactory { return &breakerFactory{} }
+
+			wrapper := func() {
+				NewBytesElement(data)
+			}
+
+			Ω(wrapper).Should(Panic())
+			baseFactory = origFac
+		})
+	})
+
+	Context("with the default marshaller", func() {
+
+		It("should serialize the bytes as base64", func() {
+			edn, err := NewBytesElement(data).Serialize(EvaEdnMimeType)
+			Ω(err).Should(BeNil())
+			Ω(edn).Should(BeEquivalentTo(`#bytes "AQID+g=="`))
+		})
+
+		It("should not serialize to an unknown mime type", func() {
+			_, err := NewBytesElement(data).Serialize(SerializerMimeType("InvalidType"))
+			Ω(err).Should(test.HaveMessage(ErrUnknownMimeType))
+		})
+
+		It("should round trip through json and transit", func() {
+			elem := NewBytesElement(data)
+
+			str, err := elem.Serialize(JSONMimeType)
+			Ω(err).Should(BeNil())
+			Ω(str).Should(BeEquivalentTo(`{"#bytes":"AQID+g=="}`))
+
+			parsed, err := ParseJSON(str)
+			Ω(err).Should(BeNil())
+			Ω(parsed.Equals(elem)).Should(BeTrue())
+
+			str, err = elem.Serialize(TransitMimeType)
+			Ω(err).Should(BeNil())
+			Ω(str).Should(BeEquivalentTo(`["~#'","~bAQID+g=="]`))
+
+			parsed, err = ParseTransit(str)
+			Ω(err).Should(BeNil())
+			Ω(parsed.Equals(elem)).Should(BeTrue())
+		})
+
+		It("should marshal and unmarshal byte slices", func() {
+			data, err := Marshal(struct {
+				Data []byte `edn:"data"`
+			}{[]byte{1, 2, 3, 250}})
+			Ω(err).Should(BeNil())
+			Ω(string(data)).Should(BeEquivalentTo(`{:data #bytes "AQID+g=="}`))
+
+			var out struct {
+				Data []byte `edn:"data"`
+				List []byte `edn:"list"`
+			}
+			Ω(Unmarshal([]byte(`{:data #bytes "AQID+g==" :list [4 5]}`), &out)).Should(BeNil())
+			Ω(out.Data).Should(BeEquivalentTo([]byte{1, 2, 3, 250}))
+			Ω(out.List).Should(BeEquivalentTo([]byte{4, 5}))
+		})
+	})
+
+	Context("Parsing", func() {
+
+		It("should parse the bytes", func() {
+			elem, err := Parse(`#bytes "AQID+g=="`)
+			Ω(err).Should(BeNil())
+			Ω(elem.ElementType()).Should(BeEquivalentTo(BytesType))
+			Ω(elem.Equals(NewBytesElement(data))).Should(BeTrue())
+		})
+
+		It("should not parse bad base64", func() {
+			_, err := Parse(`#bytes "not base64!"`)
+			Ω(err).ShouldNot(BeNil())
+		})
+	})
+})
@@ -18,19 +18,44 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"unicode"
+	"unicode/utf8"
 )
 
 const (
 
 	// CharacterPrefix defines the prefix for characters
 	CharacterPrefix = "\\"
+
+	// characterPattern is the lexer pattern for single characters, any character other than whitespace is allowed.
+	characterPattern = `\\[^\s]`
+
+	// unicodeCharacterPattern is the lexer pattern for unicode escaped characters.
+	unicodeCharacterPattern = "\\\\u[0-9A-Fa-f][0-9A-Fa-f][0-9A-Fa-f][0-9A-Fa-f]"
 )
 
+// characterTokenMatcher is a hand-written equivalent of characterPattern.
+func characterTokenMatcher(token string) bool {
+	if len(token) < 2 || token[0] != CharacterPrefix[0] {
+		return false
+	}
+
+	r, size := utf8.DecodeRuneInString(token[1:])
+	return 1+size == len(token) && !strings.ContainsRune("\t\n\f\r ", r)
+}
+
+// unicodeCharacterTokenMatcher is a hand-written equivalent of unicodeCharacterPattern.
+func unicodeCharacterTokenMatcher(token string) bool {
+	return len(token) == 6 && token[:2] == CharacterPrefix+"u" && isHex(token[2:])
+}
+
 var specialCharacters = map[rune]string{
 	'\r': "return",
 	'\n': "newline",
 	' ':  "space",
 	'\t': "tab",
+	'\f': "formfeed",
+	'\b': "backspace",
 }
 
 // init will add the element factory to the collection of factories
@@ -51,12 +76,12 @@ func initCharacter(lexer Lexer) (err error) {
 			})
 		}
 
-		lexer.AddPattern(CharacterPrimitive, "\\\\u[0-9A-Fa-f][0-9A-Fa-f][0-9A-Fa-f][0-9A-Fa-f]", func(tag string, tokenValue string) (el Element, e error) {
+		lexer.AddPattern(CharacterPrimitive, unicodeCharacterPattern, func(tag string, tokenValue string) (el Element, e error) {
 			tokenValue = strings.TrimPrefix(tokenValue, CharacterPrefix+"u")
-			var v int64
+			var v uint64
 
 			// It isn't possible to get anything other then 4 characters, so checking isn't needed.
-			if v, e = strconv.ParseInt(tokenValue, 16, 16); e == nil {
+			if v, e = strconv.ParseUint(tokenValue, 16, 32); e == nil {
 				el = NewCharacterElement(rune(v))
 				e = el.SetTag(tag)
 			}
@@ -64,7 +89,7 @@ func initCharacter(lexer Lexer) (err error) {
 			return el, e
 		})
 
-		lexer.AddPattern(CharacterPrimitive, "\\\\\\w", func(tag string, tokenValue string) (el Element, e error) {
+		lexer.AddPattern(CharacterPrimitive, characterPattern, func(tag string, tokenValue string) (el Element, e error) {
 
 			tokenValue = strings.TrimPrefix(tokenValue, CharacterPrefix)
 			runes := []rune(tokenValue)
@@ -80,6 +105,24 @@ func initCharacter(lexer Lexer) (err error) {
 	return err
 }
 
+// quoteCharacter writes the character for EDN. Printable ascii characters are written as is, the others use their
+// name or are escaped.
+func quoteCharacter(r rune) string {
+	if char, has := specialCharacters[r]; has {
+		return CharacterPrefix + char
+	}
+
+	switch {
+	case r > ' ' && r < unicode.MaxASCII:
+		return CharacterPrefix + string(r)
+	case r > 0xFFFF && utf8.ValidRune(r):
+		// characters outside of the basic plane cannot be escaped, they are written as utf-8.
+		return CharacterPrefix + string(r)
+	default:
+		return fmt.Sprintf("%su%04x", CharacterPrefix, r)
+	}
+}
+
 // NewCharacterElement creates a new character element or an error.
 func NewCharacterElement(value rune) (elem Element) {
 
@@ -92,19 +135,11 @@ func NewCharacterElement(value rune) (elem Element) {
 				out = TagPrefix + tag + " "
 			}
 
-			r := value.(rune)
-			if char, has := specialCharacters[r]; has {
-				out += CharacterPrefix + char
-			} else {
-
-				// if there is no special character, then quote the rune, remove the single quotes around this, then
-				// if it is an ASCII then make sure to prefix is intact.
-				if char = strings.Trim(fmt.Sprintf("%+q", r), "'"); strings.HasPrefix(char, CharacterPrefix) {
-					out += char
-				} else {
-					out += CharacterPrefix + char
-				}
-			}
+			out += quoteCharacter(value.(rune))
+		case JSONMimeType:
+			out = jsonTagged(tag, jsonQuote(string(value.(rune))))
+		case TransitMimeType:
+			out, e = transitString(tag, value)
 		default:
 			e = MakeError(ErrUnknownMimeType, serializer.MimeType())
 		}
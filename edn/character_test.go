@@ -81,6 +81,12 @@ var _ = Describe("Character in EDN", func() {
 			' ':  "\\space",
 			'\t': "\\tab",
 			'⌘':  "\\u2318",
+			'\f': "\\formfeed",
+			'\b': "\\backspace",
+			'(':  "\\(",
+			'é':  "\\u00e9",
+			'😀':  "\\😀",
+			'\a': "\\u0007",
 		}
 
 		It("should create an character value with no error", func() {
@@ -131,6 +137,12 @@ var _ = Describe("Character in EDN", func() {
 			&testDefinition{"\\s", 's'},
 			&testDefinition{"\\u2318", '⌘'},
 			&testDefinition{"\\u20AC", '€'},
+			&testDefinition{"\\uFFFF", '\uffff'},
+			&testDefinition{"\\formfeed", '\f'},
+			&testDefinition{"\\backspace", '\b'},
+			&testDefinition{"\\é", 'é'},
+			&testDefinition{"\\😀", '😀'},
+			&testDefinition{"\\(", '('},
 		)
 	})
 })
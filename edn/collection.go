@@ -16,13 +16,16 @@ package edn
 
 import (
 	"fmt"
-	"strconv"
+	"io"
 )
 
 const (
 
 	// ErrNoValue is returned when no value is found in the collection.
 	ErrNoValue = ErrorMessage("No value found")
+
+	// ErrInvalidKey is returned when the key cannot be used with the collection, e.g. a string index into a vector.
+	ErrInvalidKey = ErrorMessage("Invalid key")
 )
 
 // ChildIterator is the iterator for children elements, if this is a list based item, the key will be an index of the
@@ -51,6 +54,34 @@ type CollectionElement interface {
 
 	// Merge one collection into another.
 	Merge(CollectionElement) error
+
+	// Contains checks if the key is in the collection: a key of a map, an item of a set or an index of a list or
+	// vector.
+	Contains(key interface{}) bool
+
+	// Keys returns the keys of a map, the items of a set or the indexes of a list or vector.
+	Keys() []Element
+
+	// Values returns the values of a map or the items of a set, list or vector.
+	Values() []Element
+
+	// Remove the keys of a map, the items of a set or the indexes of a list or vector from this collection. Keys that
+	// are not in the collection are ignored.
+	Remove(keys ...interface{}) error
+
+	// Assoc returns a new collection with the key set to the value. The key of a list or vector is an index, which
+	// may be the length of the collection to add the value to the end.
+	Assoc(key Element, value Element) (CollectionElement, error)
+
+	// Dissoc returns a new collection without the keys, see Remove.
+	Dissoc(keys ...interface{}) (CollectionElement, error)
+
+	// Update returns a new collection with the value of the key replaced by the result of the function. The function
+	// is given nil if the key is not in the collection.
+	Update(key Element, fn func(Element) (Element, error)) (CollectionElement, error)
+
+	// Slice returns a new collection with the entries from start up to, but not including, end.
+	Slice(start int, end int) (CollectionElement, error)
 }
 
 // collectionElemImpl is the implementation to the GroupElement interface.
@@ -71,6 +102,9 @@ type collectionElemImpl struct {
 
 	// collection of elements
 	collection interface{}
+
+	// order of the serialized keys of maps and sets, in the order they were added.
+	order []string
 }
 
 // Len return the quantity of items in this collection.
@@ -95,7 +129,8 @@ func (elem *collectionElemImpl) IterateChildren(iterator ChildIterator) (err err
 			}
 		}
 	case map[string][2]Element:
-		for _, c := range v {
+		for _, k := range elem.order {
+			c := v[k]
 			err = iterator(c[0], c[1])
 			if err != nil {
 				break
@@ -105,53 +140,106 @@ func (elem *collectionElemImpl) IterateChildren(iterator ChildIterator) (err err
 	return err
 }
 
-// collectionSerialization the element into a string or return the appropriate error.
-func collectionSerialization(hasKey bool) func(serializer Serializer, tag string, value interface{}) (composition string, err error) {
+// iterate will iterate over the child elements without creating the index keys for lists, in which case the key is
+// nil.
+func (elem *collectionElemImpl) iterate(iterator ChildIterator) (err error) {
+	switch v := elem.collection.(type) {
+	case []Element:
+		for _, c := range v {
+			if err = iterator(nil, c); err != nil {
+				break
+			}
+		}
+	case map[string][2]Element:
+		for _, k := range elem.order {
+			c := v[k]
+			if err = iterator(c[0], c[1]); err != nil {
+				break
+			}
+		}
+	}
+	return err
+}
 
-	return func(serializer Serializer, tag string, value interface{}) (composition string, err error) {
+// writeCollection streams the collection onto the writer on a single line.
+func writeCollection(writer io.Writer, serializer Serializer, tag string, val *collectionElemImpl, hasKey bool) (err error) {
 
-		switch serializer.MimeType() {
-		case EvaEdnMimeType:
-			if len(tag) > 0 {
-				composition = TagPrefix + tag + " "
+	if len(tag) > 0 {
+		_, err = io.WriteString(writer, TagPrefix+tag+" ")
+	}
+
+	if err == nil {
+		_, err = io.WriteString(writer, val.startSymbol)
+	}
+
+	var entries [][2]Element
+	if err == nil {
+		entries, err = val.entries(serializer)
+	}
+
+	for i := 0; i < len(entries) && err == nil; i++ {
+		key, child := entries[i][0], entries[i][1]
+		if i > 0 {
+			_, err = io.WriteString(writer, val.separatorSymbol)
+		}
+
+		if err == nil && hasKey {
+			if err = key.SerializeTo(writer, serializer); err == nil {
+				_, err = io.WriteString(writer, val.keyValueSeparatorSymbol)
 			}
+		}
 
-			val := value.(*collectionElemImpl)
-			composition += val.startSymbol
+		if err == nil && child != nil {
+			err = child.SerializeTo(writer, serializer)
+		}
+	}
 
-			first := true
-			if err = val.IterateChildren(func(key Element, child Element) (e error) {
-				if first {
-					first = false
-				} else {
-					composition += val.separatorSymbol
-				}
+	if err == nil {
+		_, err = io.WriteString(writer, val.endSymbol)
+	}
 
-				var c string
-				if hasKey {
-					if c, e = key.Serialize(serializer); e == nil {
-						composition += c + val.keyValueSeparatorSymbol
-					}
-				}
+	return err
+}
 
-				if e == nil && child != nil {
-					if c, e = child.Serialize(serializer); e == nil {
-						composition += c
-					}
-				}
+// collectionWriter streams the element onto the writer or returns the appropriate error.
+func collectionWriter(hasKey bool) writerFunc {
+
+	return func(writer io.Writer, serializer Serializer, tag string, value interface{}) (err error) {
 
-				return e
-			}); err == nil {
-				composition += val.endSymbol
+		switch serializer.MimeType() {
+		case EvaEdnMimeType:
+			var options PrettyOptions
+			var pretty bool
+			if options, pretty, err = prettyOptions(serializer); err == nil {
+				if pretty {
+					err = newPrettyPrinter(writer, serializer, options).print(value.(*collectionElemImpl), 0)
+				} else {
+					err = writeCollection(writer, serializer, tag, value.(*collectionElemImpl), hasKey)
+				}
 			}
+		case JSONMimeType:
+			err = writeJSONCollection(writer, serializer, tag, value.(*collectionElemImpl), hasKey)
+		case TransitMimeType:
+			err = (&transitEncoder{writer: writer, serializer: serializer}).encode(tag, value, false)
 		default:
 			err = MakeError(ErrUnknownMimeType, serializer.MimeType())
 		}
 
-		return composition, err
+		return err
 	}
 }
 
+// makeCollectionBase creates the base element for the collection, the collection is streamed when serialized.
+func makeCollectionBase(coll *collectionElemImpl, elemType ElementType, hasKey bool) (base *baseElemImpl, err error) {
+
+	writer := collectionWriter(hasKey)
+	if base, err = baseFactory().make(coll, elemType, stringerFromWriter(writer)); err == nil {
+		base.writer = writer
+	}
+
+	return base, err
+}
+
 // Equals checks if the input element is equal to this element.
 func (elem *collectionElemImpl) Equals(e Element) (result bool) {
 	if elem.ElementType() == e.ElementType() {
@@ -195,49 +283,52 @@ func (elem *collectionElemImpl) Equals(e Element) (result bool) {
 	return result
 }
 
-func (elem *collectionElemImpl) add(addFunc func([]Element, []Element) []Element, children []Element) (err error) {
+// add the children to the collection, at the front if prepend is set.
+func (elem *collectionElemImpl) add(prepend bool, children []Element) (err error) {
 
 	if len(children) != 0 {
 		switch v := elem.collection.(type) {
 		case []Element:
-			elem.collection = addFunc(v, children)
+			if prepend {
+				elem.collection = append(children, v...)
+			} else {
+				elem.collection = append(v, children...)
+			}
 		case map[string][2]Element:
+			setSize := 2 // This is for maps...
+			if len(elem.keyValueSeparatorSymbol) == 0 {
+				setSize = 1 // This is for sets...
+			}
 
-			var serializer Serializer
-			if serializer, err = GetSerializerByType(EvaEdnMimeType); err == nil {
-				setSize := 2 // This is for maps...
-				if len(elem.keyValueSeparatorSymbol) == 0 {
-					setSize = 1 // This is for sets...
-				}
-
-				if len(children)%setSize == 0 {
-					childOffset := setSize - 1
+			if len(children)%setSize == 0 {
+				childOffset := setSize - 1
+				added := make([]string, 0, len(children)/setSize)
 
-					for i := 0; i < len(children); i += setSize {
-						var k string
-						if str, is := children[i].(*baseElemImpl); is && str.elemType == StringType {
-							k = str.value.(string)
+				for i := 0; i < len(children); i += setSize {
+					var k string
+					if k, err = collectionKey(children[i]); err == nil {
+						if _, has := v[k]; !has {
+							v[k] = [2]Element{children[i], children[i+childOffset]}
+							added = append(added, k)
 						} else {
-							k, err = children[i].Serialize(serializer)
-						}
-
-						if err == nil {
-							if _, has := v[k]; !has {
-								v[k] = [2]Element{children[i], children[i+childOffset]}
-							} else {
-								err = MakeErrorWithFormat(ErrDuplicateKey, "Key: %s", k)
-							}
+							err = MakeErrorWithFormat(ErrDuplicateKey, "Key: %s", k)
 						}
+					}
 
-						if err != nil {
-							break
-						}
+					if err != nil {
+						break
 					}
+				}
+
+				// the keys added before an error are kept in the collection.
+				if prepend {
+					elem.order = append(added, elem.order...)
 				} else {
-					err = MakeError(ErrInvalidInput, "must have an even number of inputs.")
+					elem.order = append(elem.order, added...)
 				}
+			} else {
+				err = MakeError(ErrInvalidInput, "must have an even number of inputs.")
 			}
-
 		default:
 			err = MakeErrorWithFormat(ErrInvalidElement, "type: %T", v)
 		}
@@ -246,24 +337,32 @@ func (elem *collectionElemImpl) add(addFunc func([]Element, []Element) []Element
 	return err
 }
 
+// keySerializer writes the keys of maps and sets. It is canonical so that equal keys, such as maps with the same
+// entries added in a different order, are stored under the same key.
+const keySerializer = EvaEdnMimeType + ";" + CanonicalOption + "=true"
+
+// collectionKey returns the key that the element is stored under in a map or set.
+func collectionKey(key Element) (k string, err error) {
+	if str, is := key.(*baseElemImpl); is && str.elemType == StringType {
+		k = str.value.(string)
+	} else {
+		k, err = key.Serialize(keySerializer)
+	}
+	return k, err
+}
+
 // Append will add the appropriate children. Note that a map must have 2 parameters.
 func (elem *collectionElemImpl) Append(children ...Element) error {
-	return elem.add(func(in []Element, c []Element) []Element {
-		return append(in, c...)
-	}, children)
+	return elem.add(false, children)
 }
 
 // Prepend will add the appropriate children. Note that a map must have 2 parameters.
 func (elem *collectionElemImpl) Prepend(children ...Element) error {
-	return elem.add(func(in []Element, c []Element) []Element {
-		return append(c, in...)
-	}, children)
+	return elem.add(true, children)
 }
 
-// Get the value from the collection.
-func (elem *collectionElemImpl) Get(key interface{}) (value Element, err error) {
-
-	var realKey string
+// lookupKey returns the key used to look up the input in the collection.
+func lookupKey(key interface{}) (realKey string, err error) {
 
 	switch k := key.(type) {
 	case int, int32, int64:
@@ -271,26 +370,55 @@ func (elem *collectionElemImpl) Get(key interface{}) (value Element, err error)
 	case string:
 		realKey = k
 	case Element:
-		var serializer Serializer
-		if serializer, err = GetSerializerByType(EvaEdnMimeType); err == nil {
-			realKey, err = k.Serialize(serializer)
+		realKey, err = collectionKey(k)
+	default:
+		err = MakeErrorWithFormat(ErrInvalidInput, "key type: %T", k)
+	}
+
+	return realKey, err
+}
+
+// indexKey returns the index used to look up the input in a list or vector. Only integers are indexes, other keys
+// are ErrInvalidKey.
+func indexKey(key interface{}) (index int64, err error) {
+
+	switch k := key.(type) {
+	case int:
+		index = int64(k)
+	case int32:
+		index = int64(k)
+	case int64:
+		index = k
+	case string:
+		err = MakeErrorWithFormat(ErrInvalidKey, "index: %q", k)
+	case Element:
+		var is bool
+		if index, is = k.Value().(int64); !is || k.ElementType() != IntegerType {
+			err = MakeErrorWithFormat(ErrInvalidKey, "index: %s", k.ElementType().Name())
 		}
 	default:
 		err = MakeErrorWithFormat(ErrInvalidInput, "key type: %T", k)
 	}
 
-	if err == nil {
-		switch v := elem.collection.(type) {
-		case []Element:
-			var index int
-			if index, err = strconv.Atoi(realKey); err == nil {
-				if index >= 0 && index < len(v) {
-					value = v[index]
-				} else {
-					err = MakeError(ErrNoValue, realKey)
-				}
+	return index, err
+}
+
+// Get the value from the collection.
+func (elem *collectionElemImpl) Get(key interface{}) (value Element, err error) {
+
+	switch v := elem.collection.(type) {
+	case []Element:
+		var index int64
+		if index, err = indexKey(key); err == nil {
+			if index >= 0 && index < int64(len(v)) {
+				value = v[index]
+			} else {
+				err = MakeErrorWithFormat(ErrNoValue, "%d", index)
 			}
-		case map[string][2]Element:
+		}
+	case map[string][2]Element:
+		var realKey string
+		if realKey, err = lookupKey(key); err == nil {
 			var has bool
 			var pair [2]Element
 			if pair, has = v[realKey]; has {
@@ -298,9 +426,9 @@ func (elem *collectionElemImpl) Get(key interface{}) (value Element, err error)
 			} else {
 				err = MakeError(ErrNoValue, realKey)
 			}
-		default:
-			err = MakeErrorWithFormat(ErrInvalidElement, "type: %T", v)
 		}
+	default:
+		err = MakeErrorWithFormat(ErrInvalidElement, "type: %T", v)
 	}
 
 	return value, err
@@ -323,3 +451,240 @@ func (elem *collectionElemImpl) Merge(child CollectionElement) (err error) {
 
 	return err
 }
+
+// Contains checks if the key is in the collection.
+func (elem *collectionElemImpl) Contains(key interface{}) (has bool) {
+	switch v := elem.collection.(type) {
+	case []Element:
+		if index, err := indexKey(key); err == nil {
+			has = index >= 0 && index < int64(len(v))
+		}
+	case map[string][2]Element:
+		if realKey, err := lookupKey(key); err == nil {
+			_, has = v[realKey]
+		}
+	}
+	return has
+}
+
+// Keys returns the keys of the collection in order.
+func (elem *collectionElemImpl) Keys() (keys []Element) {
+	keys = make([]Element, 0, elem.Len())
+	_ = elem.IterateChildren(func(key Element, _ Element) error {
+		keys = append(keys, key)
+		return nil
+	})
+	return keys
+}
+
+// Values returns the values of the collection in order.
+func (elem *collectionElemImpl) Values() (values []Element) {
+	values = make([]Element, 0, elem.Len())
+	_ = elem.iterate(func(_ Element, value Element) error {
+		values = append(values, value)
+		return nil
+	})
+	return values
+}
+
+// Remove the keys from the collection.
+func (elem *collectionElemImpl) Remove(keys ...interface{}) (err error) {
+
+	switch v := elem.collection.(type) {
+	case []Element:
+		removed := map[int64]bool{}
+		for _, key := range keys {
+			var index int64
+			if index, err = indexKey(key); err != nil {
+				return err
+			}
+			removed[index] = true
+		}
+
+		children := make([]Element, 0, len(v))
+		for index, child := range v {
+			if !removed[int64(index)] {
+				children = append(children, child)
+			}
+		}
+		elem.collection = children
+	case map[string][2]Element:
+		removed := map[string]bool{}
+		for _, key := range keys {
+			var realKey string
+			if realKey, err = lookupKey(key); err != nil {
+				return err
+			}
+			removed[realKey] = true
+		}
+
+		order := make([]string, 0, len(elem.order))
+		for _, k := range elem.order {
+			if removed[k] {
+				delete(v, k)
+			} else {
+				order = append(order, k)
+			}
+		}
+		elem.order = order
+	}
+
+	return err
+}
+
+// Assoc returns a new collection with the key set to the value.
+func (elem *collectionElemImpl) Assoc(key Element, value Element) (coll CollectionElement, err error) {
+
+	var clone *collectionElemImpl
+	if clone, err = elem.clone(); err == nil {
+		if err = clone.set(key, value); err == nil {
+			coll = clone
+		}
+	}
+
+	return coll, err
+}
+
+// Dissoc returns a new collection without the keys.
+func (elem *collectionElemImpl) Dissoc(keys ...interface{}) (coll CollectionElement, err error) {
+
+	var clone *collectionElemImpl
+	if clone, err = elem.clone(); err == nil {
+		if err = clone.Remove(keys...); err == nil {
+			coll = clone
+		}
+	}
+
+	return coll, err
+}
+
+// Update returns a new collection with the value of the key replaced by the result of the function.
+func (elem *collectionElemImpl) Update(key Element, fn func(Element) (Element, error)) (coll CollectionElement, err error) {
+
+	if key == nil || fn == nil {
+		return nil, MakeError(ErrInvalidInput, "nil key or function")
+	}
+
+	var value Element
+	if elem.Contains(key) {
+		if value, err = elem.Get(key); err != nil {
+			return nil, err
+		}
+	}
+
+	if value, err = fn(value); err == nil {
+		coll, err = elem.Assoc(key, value)
+	}
+
+	return coll, err
+}
+
+// Slice returns a new collection with the entries from start up to, but not including, end.
+func (elem *collectionElemImpl) Slice(start int, end int) (coll CollectionElement, err error) {
+
+	if start < 0 || end < start || end > elem.Len() {
+		return nil, MakeErrorWithFormat(ErrInvalidInput, "slice [%d:%d] of %d entries", start, end, elem.Len())
+	}
+
+	var clone *collectionElemImpl
+	if clone, err = elem.clone(); err == nil {
+		switch v := clone.collection.(type) {
+		case []Element:
+			clone.collection = v[start:end]
+		case map[string][2]Element:
+			for i, k := range clone.order {
+				if i < start || i >= end {
+					delete(v, k)
+				}
+			}
+			clone.order = clone.order[start:end]
+		}
+
+		coll = clone
+	}
+
+	return coll, err
+}
+
+// clone returns a shallow copy of the collection with the same type and tag.
+func (elem *collectionElemImpl) clone() (clone *collectionElemImpl, err error) {
+
+	if clone, err = elem.empty(); err == nil {
+		clone.order = append([]string(nil), elem.order...)
+
+		switch v := elem.collection.(type) {
+		case []Element:
+			clone.collection = append([]Element(nil), v...)
+		case map[string][2]Element:
+			children := make(map[string][2]Element, len(v))
+			for k, pair := range v {
+				children[k] = pair
+			}
+			clone.collection = children
+		}
+	}
+
+	return clone, err
+}
+
+// empty returns an empty collection with the same type and tag.
+func (elem *collectionElemImpl) empty() (empty *collectionElemImpl, err error) {
+
+	empty = &collectionElemImpl{
+		startSymbol:             elem.startSymbol,
+		endSymbol:               elem.endSymbol,
+		separatorSymbol:         elem.separatorSymbol,
+		keyValueSeparatorSymbol: elem.keyValueSeparatorSymbol,
+	}
+
+	switch elem.collection.(type) {
+	case []Element:
+		empty.collection = []Element{}
+	case map[string][2]Element:
+		empty.collection = map[string][2]Element{}
+	}
+
+	var base *baseElemImpl
+	if base, err = makeCollectionBase(empty, elem.ElementType(), elem.ElementType() == MapType); err == nil {
+		empty.baseElemImpl = base
+		err = empty.SetTag(elem.Tag())
+	}
+
+	return empty, err
+}
+
+// set the value of the key, adding the key if it is not in the collection.
+func (elem *collectionElemImpl) set(key Element, value Element) (err error) {
+
+	if key == nil || value == nil {
+		return MakeError(ErrInvalidInput, "nil key or value")
+	}
+
+	switch v := elem.collection.(type) {
+	case []Element:
+		index, is := key.Value().(int64)
+		switch {
+		case !is || index < 0 || index > int64(len(v)):
+			err = MakeErrorWithFormat(ErrInvalidInput, "index %s of %d entries", key, len(v))
+		case index == int64(len(v)):
+			elem.collection = append(v, value)
+		default:
+			v[index] = value
+		}
+	case map[string][2]Element:
+		if elem.ElementType() != MapType {
+			err = MakeErrorWithFormat(ErrInvalidInput, "%s has no values", elem.ElementType())
+		} else {
+			var k string
+			if k, err = collectionKey(key); err == nil {
+				if _, has := v[k]; has {
+					v[k] = [2]Element{key, value}
+				} else {
+					err = elem.Append(key, value)
+				}
+			}
+		}
+	}
+
+	return err
+}
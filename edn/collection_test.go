@@ -0,0 +1,280 @@
+// Copyright 2018-2019 Workiva Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package edn
+
+import (
+	"github.com/Workiva/eva-client-go/test"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Collection updates in EDN", func() {
+
+	mustParse := func(str string) CollectionElement {
+		elem, err := Parse(str)
+		Ω(err).Should(BeNil())
+		return elem.(CollectionElement)
+	}
+
+	keyword := func(name string) Element {
+		elem, err := NewKeywordElement(name)
+		Ω(err).Should(BeNil())
+		return elem
+	}
+
+	strings := func(elements []Element) (out []string) {
+		for _, elem := range elements {
+			out = append(out, elem.String())
+		}
+		return out
+	}
+
+	Context("reading", func() {
+
+		It("should check if the collection contains the key", func() {
+			m := mustParse(`{:a 1 "b" 2}`)
+			Ω(m.Contains(keyword("a"))).Should(BeTrue())
+			Ω(m.Contains(":a")).Should(BeTrue())
+			Ω(m.Contains(NewStringElement("b"))).Should(BeTrue())
+			Ω(m.Contains(keyword("b"))).Should(BeFalse())
+			Ω(m.Contains(1.5)).Should(BeFalse())
+
+			v := mustParse("[:a :b]")
+			Ω(v.Contains(0)).Should(BeTrue())
+			Ω(v.Contains(NewIntegerElement(1))).Should(BeTrue())
+			Ω(v.Contains(2)).Should(BeFalse())
+			Ω(v.Contains(-1)).Should(BeFalse())
+			Ω(v.Contains("1")).Should(BeFalse())
+			Ω(v.Contains(NewStringElement("1"))).Should(BeFalse())
+			Ω(v.Contains(keyword("a"))).Should(BeFalse())
+
+			s := mustParse("#{:a 1}")
+			Ω(s.Contains(keyword("a"))).Should(BeTrue())
+			Ω(s.Contains(NewIntegerElement(1))).Should(BeTrue())
+			Ω(s.Contains(NewIntegerElement(2))).Should(BeFalse())
+		})
+
+		It("should return the keys and values in order", func() {
+			m := mustParse(`{:b 1 :a 2}`)
+			Ω(strings(m.Keys())).Should(Equal([]string{":b", ":a"}))
+			Ω(strings(m.Values())).Should(Equal([]string{"1", "2"}))
+
+			l := mustParse("(:x :y)")
+			Ω(strings(l.Keys())).Should(Equal([]string{"0", "1"}))
+			Ω(strings(l.Values())).Should(Equal([]string{":x", ":y"}))
+
+			s := mustParse("#{3 1}")
+			Ω(strings(s.Keys())).Should(Equal([]string{"3", "1"}))
+			Ω(strings(s.Values())).Should(Equal([]string{"3", "1"}))
+
+			Ω(mustParse("[]").Keys()).Should(BeEmpty())
+		})
+	})
+
+	Context("removing", func() {
+
+		It("should remove from the collection", func() {
+			m := mustParse(`{:a 1 :b 2 :c 3}`)
+			Ω(m.Remove(keyword("b"), ":c", keyword("missing"))).Should(BeNil())
+			Ω(m.String()).Should(BeEquivalentTo("{:a 1}"))
+			Ω(m.Len()).Should(Equal(1))
+
+			v := mustParse("[:a :b :c :d]")
+			Ω(v.Remove(0, NewIntegerElement(2), 10)).Should(BeNil())
+			Ω(v.String()).Should(BeEquivalentTo("[:b :d]"))
+
+			s := mustParse("#{1 2 3}")
+			Ω(s.Remove(NewIntegerElement(2))).Should(BeNil())
+			Ω(s.String()).Should(BeEquivalentTo("#{1 3}"))
+			Ω(s.Append(NewIntegerElement(2))).Should(BeNil())
+			Ω(s.String()).Should(BeEquivalentTo("#{1 3 2}"))
+		})
+
+		It("should not remove keys of an unknown type", func() {
+			m := mustParse(`{:a 1}`)
+			err := m.Remove(":a", 1.5)
+			Ω(err).ShouldNot(BeNil())
+			Ω(err).Should(test.HaveMessage(ErrInvalidInput))
+			Ω(m.Len()).Should(Equal(1))
+		})
+
+		It("should only use integers as the keys of lists and vectors", func() {
+			for _, str := range []string{"[:a :b]", "(:a :b)"} {
+				coll := mustParse(str)
+				for _, key := range []interface{}{"1", NewStringElement("1"), keyword("a"), mustParse("[1]")} {
+					_, err := coll.Get(key)
+					Ω(err).Should(test.HaveMessage(ErrInvalidKey))
+					Ω(coll.Remove(key)).Should(test.HaveMessage(ErrInvalidKey))
+					Ω(coll.Len()).Should(Equal(2))
+				}
+
+				Ω(coll.Remove(int32(1))).Should(BeNil())
+				Ω(coll.Values()).Should(HaveLen(1))
+			}
+		})
+
+		It("should dissoc without changing the original", func() {
+			m := mustParse(`#my/tag {:a 1 :b 2}`)
+			d, err := m.Dissoc(keyword("a"))
+			Ω(err).Should(BeNil())
+			Ω(d.String()).Should(BeEquivalentTo("#my/tag {:b 2}"))
+			Ω(m.String()).Should(BeEquivalentTo("#my/tag {:a 1, :b 2}"))
+
+			_, err = m.Dissoc(struct{}{})
+			Ω(err).Should(test.HaveMessage(ErrInvalidInput))
+		})
+	})
+
+	Context("associating", func() {
+
+		It("should assoc without changing the original", func() {
+			m := mustParse(`{:a 1 :b 2}`)
+
+			a, err := m.Assoc(keyword("a"), NewIntegerElement(10))
+			Ω(err).Should(BeNil())
+			Ω(a.String()).Should(BeEquivalentTo("{:a 10, :b 2}"))
+
+			a, err = a.Assoc(keyword("c"), NewIntegerElement(3))
+			Ω(err).Should(BeNil())
+			Ω(a.String()).Should(BeEquivalentTo("{:a 10, :b 2, :c 3}"))
+			Ω(a.ElementType()).Should(Equal(MapType))
+			Ω(m.String()).Should(BeEquivalentTo("{:a 1, :b 2}"))
+		})
+
+		It("should assoc the indexes of vectors and lists", func() {
+			v := mustParse("[:a :b]")
+
+			a, err := v.Assoc(NewIntegerElement(1), keyword("c"))
+			Ω(err).Should(BeNil())
+			Ω(a.String()).Should(BeEquivalentTo("[:a :c]"))
+
+			a, err = a.Assoc(NewIntegerElement(2), keyword("d"))
+			Ω(err).Should(BeNil())
+			Ω(a.String()).Should(BeEquivalentTo("[:a :c :d]"))
+			Ω(v.String()).Should(BeEquivalentTo("[:a :b]"))
+
+			l, err := mustParse("(1 2)").Assoc(NewIntegerElement(0), NewIntegerElement(3))
+			Ω(err).Should(BeNil())
+			Ω(l.String()).Should(BeEquivalentTo("(3 2)"))
+			Ω(l.ElementType()).Should(Equal(ListType))
+		})
+
+		It("should not assoc invalid keys", func() {
+			v := mustParse("[:a :b]")
+			for _, key := range []Element{NewIntegerElement(3), NewIntegerElement(-1), keyword("a")} {
+				_, err := v.Assoc(key, keyword("c"))
+				Ω(err).Should(test.HaveMessage(ErrInvalidInput))
+			}
+
+			_, err := mustParse("#{1}").Assoc(NewIntegerElement(2), NewIntegerElement(2))
+			Ω(err).Should(test.HaveMessage(ErrInvalidInput))
+
+			_, err = mustParse("{}").Assoc(nil, NewIntegerElement(2))
+			Ω(err).Should(test.HaveMessage(ErrInvalidInput))
+		})
+
+		It("should update the values", func() {
+			inc := func(elem Element) (Element, error) {
+				if elem == nil {
+					return NewIntegerElement(1), nil
+				}
+				return NewIntegerElement(elem.Value().(int64) + 1), nil
+			}
+
+			m := mustParse(`{:a 1 "b" 5}`)
+			u, err := m.Update(keyword("a"), inc)
+			Ω(err).Should(BeNil())
+			u, err = u.Update(NewStringElement("b"), inc)
+			Ω(err).Should(BeNil())
+			u, err = u.Update(keyword("c"), inc)
+			Ω(err).Should(BeNil())
+			Ω(u.String()).Should(BeEquivalentTo(`{:a 2, "b" 6, :c 1}`))
+			Ω(m.String()).Should(BeEquivalentTo(`{:a 1, "b" 5}`))
+
+			v, err := mustParse("[1 2]").Update(NewIntegerElement(1), inc)
+			Ω(err).Should(BeNil())
+			Ω(v.String()).Should(BeEquivalentTo("[1 3]"))
+		})
+
+		It("should return the error of the update function", func() {
+			_, err := mustParse(`{:a 1}`).Update(keyword("a"), func(Element) (Element, error) {
+				return nil, MakeError(ErrInvalidElement, "bad")
+			})
+			Ω(err).Should(test.HaveMessage(ErrInvalidElement))
+
+			_, err = mustParse(`{:a 1}`).Update(keyword("a"), nil)
+			Ω(err).Should(test.HaveMessage(ErrInvalidInput))
+		})
+	})
+
+	Context("slicing", func() {
+
+		It("should slice the collection", func() {
+			v := mustParse("#tag [1 2 3 4]")
+			s, err := v.Slice(1, 3)
+			Ω(err).Should(BeNil())
+			Ω(s.String()).Should(BeEquivalentTo("#tag [2 3]"))
+
+			Ω(s.Append(NewIntegerElement(5))).Should(BeNil())
+			Ω(v.String()).Should(BeEquivalentTo("#tag [1 2 3 4]"))
+
+			m, err := mustParse("{:a 1 :b 2 :c 3}").Slice(1, 3)
+			Ω(err).Should(BeNil())
+			Ω(m.String()).Should(BeEquivalentTo("{:b 2, :c 3}"))
+			Ω(m.Contains(":a")).Should(BeFalse())
+
+			e, err := mustParse("(1 2)").Slice(2, 2)
+			Ω(err).Should(BeNil())
+			Ω(e.String()).Should(BeEquivalentTo("()"))
+		})
+
+		It("should not slice out of range", func() {
+			v := mustParse("[1 2 3]")
+			for _, r := range [][2]int{{-1, 2}, {2, 1}, {0, 4}} {
+				_, err := v.Slice(r[0], r[1])
+				Ω(err).Should(test.HaveMessage(ErrInvalidInput))
+			}
+		})
+	})
+
+	Context("keys", func() {
+
+		It("should treat collection keys with entries in a different order as the same key", func() {
+			for _, str := range []string{
+				"#{{:a 1 :b 2} {:b 2 :a 1}}",
+				"#{#{1 2 3} #{3 2 1}}",
+				"{{:a 1 :b 2} :x {:b 2 :a 1} :y}",
+			} {
+				_, err := Parse(str)
+				Ω(err).Should(test.HaveMessage(ErrDuplicateKey))
+			}
+		})
+
+		It("should compare collection keys with entries in a different order as equal", func() {
+			Ω(mustParse("#{{:a 1 :b 2}}").Equals(mustParse("#{{:b 2 :a 1}}"))).Should(BeTrue())
+			Ω(mustParse("#{#{1 2} #{3}}").Equals(mustParse("#{#{3} #{2 1}}"))).Should(BeTrue())
+			Ω(mustParse("{{:a 1 :b 2} :x}").Equals(mustParse("{{:b 2 :a 1} :x}"))).Should(BeTrue())
+			Ω(mustParse("{{:a 1 :b 2} :x}").Equals(mustParse("{{:b 2 :a 2} :x}"))).Should(BeFalse())
+		})
+
+		It("should look up collection keys with entries in a different order", func() {
+			m := mustParse("{{:a 1 :b 2} :x}")
+			value, err := m.Get(mustParse("{:b 2 :a 1}"))
+			Ω(err).Should(BeNil())
+			Ω(value.String()).Should(BeEquivalentTo(":x"))
+			Ω(mustParse("#{#{1 2}}").Contains(mustParse("#{2 1}"))).Should(BeTrue())
+		})
+	})
+})
@@ -0,0 +1,300 @@
+// Copyright 2018-2019 Workiva Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package edn
+
+import (
+	"bytes"
+	"math"
+	"math/big"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mattrobenolt/gocql/uuid"
+)
+
+// CanonicalOption is the serializer option to write the entries of maps and sets in the order given by Compare, e.g.
+// application/vnd.eva+edn;canonical=true. Decimals are written without trailing zeros, e.g. 1.00M as 1M. Equal elements
+// are then always serialized to the same output.
+const CanonicalOption = "canonical"
+
+// typeRanks orders the element types against each other, numbers share the same rank so they are compared by value.
+var typeRanks = map[ElementType]int{
+	NilType:       0,
+	BooleanType:   1,
+	IntegerType:   2,
+	BigIntType:    2,
+	FloatType:     2,
+	DoubleType:    2,
+	BigDecType:    2,
+	CharacterType: 3,
+	StringType:    4,
+	SymbolType:    5,
+	KeywordType:   6,
+	InstantType:   7,
+	UUIDType:      8,
+	URIType:       9,
+	BytesType:     10,
+	ListType:      11,
+	VectorType:    12,
+	SetType:       13,
+	MapType:       14,
+}
+
+// numberRanks breaks the ties between numbers of different types that have the same value.
+var numberRanks = map[ElementType]int{
+	IntegerType: 0,
+	BigIntType:  1,
+	FloatType:   2,
+	DoubleType:  3,
+	BigDecType:  4,
+}
+
+// Compare orders the elements: -1 if a sorts before b, 0 if they are equal and 1 if a sorts after b. Elements of
+// different types are ordered by type, nil first and then booleans, numbers, characters, strings, symbols, keywords,
+// instants, UUIDs, URIs, bytes, lists, vectors, sets and maps. Numbers are ordered by value whatever their type,
+// symbols and keywords by namespace and then name, and collections by size and then by their entries in order.
+// Untagged elements sort before tagged ones, which are ordered by tag. Other elements are ordered by their EDN.
+func Compare(a Element, b Element) (result int) {
+
+	rankA, knownA := typeRanks[a.ElementType()]
+	rankB, knownB := typeRanks[b.ElementType()]
+
+	switch {
+	case knownA != knownB:
+		// unknown types sort last.
+		result = compareBool(knownA, knownB)
+	case rankA != rankB:
+		result = compareInt(int64(rankA), int64(rankB))
+	case a.Tag() != b.Tag():
+		result = strings.Compare(a.Tag(), b.Tag())
+	case !knownA:
+		result = compareSerialized(a, b)
+	case rankA == typeRanks[IntegerType]:
+		if result = compareNumbers(a.Value(), b.Value()); result == 0 {
+			result = compareInt(int64(numberRanks[a.ElementType()]), int64(numberRanks[b.ElementType()]))
+		}
+	default:
+		result = compareValues(a, b)
+	}
+
+	return result
+}
+
+// compareValues compares the elements of the same type.
+func compareValues(a Element, b Element) (result int) {
+
+	switch va := a.Value().(type) {
+	case nil:
+	case bool:
+		result = compareBool(va, b.Value().(bool))
+	case rune:
+		result = compareInt(int64(va), int64(b.Value().(rune)))
+	case string:
+		result = strings.Compare(va, b.Value().(string))
+	case time.Time:
+		switch vb := b.Value().(time.Time); {
+		case va.Before(vb):
+			result = -1
+		case va.After(vb):
+			result = 1
+		}
+	case uuid.UUID:
+		vb := b.Value().(uuid.UUID)
+		result = bytes.Compare(va[:], vb[:])
+	case *url.URL:
+		result = strings.Compare(va.String(), b.Value().(*url.URL).String())
+	case []byte:
+		result = bytes.Compare(va, b.Value().([]byte))
+	default:
+		switch ea := a.(type) {
+		case SymbolElement:
+			eb := b.(SymbolElement)
+			if result = compareBool(len(ea.Prefix()) > 0, len(eb.Prefix()) > 0); result == 0 {
+				if result = strings.Compare(ea.Prefix(), eb.Prefix()); result == 0 {
+					result = strings.Compare(ea.Name(), eb.Name())
+				}
+			}
+		case *collectionElemImpl:
+			result = compareCollections(ea, b.(*collectionElemImpl))
+		default:
+			result = compareSerialized(a, b)
+		}
+	}
+
+	return result
+}
+
+// compareCollections compares collections of the same type by size and then by their entries in order. The entries
+// of maps and sets are sorted first.
+func compareCollections(a *collectionElemImpl, b *collectionElemImpl) (result int) {
+
+	if result = compareInt(int64(a.Len()), int64(b.Len())); result == 0 {
+		entriesA, entriesB := a.sortedEntries(), b.sortedEntries()
+		for i := 0; i < len(entriesA) && result == 0; i++ {
+			for j := 0; j < 2 && result == 0; j++ {
+				if entriesA[i][j] != nil {
+					result = Compare(entriesA[i][j], entriesB[i][j])
+				}
+			}
+		}
+	}
+
+	return result
+}
+
+// compareNumbers compares the numeric values. Not a number sorts after all the other numbers.
+func compareNumbers(a interface{}, b interface{}) (result int) {
+
+	if ia, is := a.(int64); is {
+		if ib, is := b.(int64); is {
+			return compareInt(ia, ib)
+		}
+	}
+
+	fa, isFloatA := a.(float64)
+	fb, isFloatB := b.(float64)
+	switch nanA, nanB := isFloatA && math.IsNaN(fa), isFloatB && math.IsNaN(fb); {
+	case nanA || nanB:
+		result = compareBool(nanA, nanB)
+	case isFloatA && math.IsInf(fa, 0), isFloatB && math.IsInf(fb, 0), isFloatA && isFloatB:
+		// only one of them can be a big number.
+		if !isFloatA {
+			fa = -math.Copysign(1, fb)
+		} else if !isFloatB {
+			fb = -math.Copysign(1, fa)
+		}
+
+		switch {
+		case fa < fb:
+			result = -1
+		case fa > fb:
+			result = 1
+		}
+	default:
+		result = numberRat(a).Cmp(numberRat(b))
+	}
+
+	return result
+}
+
+// numberRat converts the finite numeric value into a rational.
+func numberRat(value interface{}) (rat *big.Rat) {
+
+	rat = new(big.Rat)
+	switch v := value.(type) {
+	case int64:
+		rat.SetInt64(v)
+	case *big.Int:
+		rat.SetInt(v)
+	case float64:
+		rat.SetFloat64(v)
+	case *Decimal:
+		scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(abs32(v.Scale()))), nil)
+		if v.Scale() >= 0 {
+			rat.SetFrac(v.Unscaled(), scale)
+		} else {
+			rat.SetInt(scale.Mul(scale, v.Unscaled()))
+		}
+	}
+
+	return rat
+}
+
+// compareSerialized compares the elements by their EDN.
+func compareSerialized(a Element, b Element) int {
+	strA, _ := a.Serialize(EvaEdnMimeType)
+	strB, _ := b.Serialize(EvaEdnMimeType)
+	return strings.Compare(strA, strB)
+}
+
+// compareInt compares the integers.
+func compareInt(a int64, b int64) (result int) {
+	switch {
+	case a < b:
+		result = -1
+	case a > b:
+		result = 1
+	}
+	return result
+}
+
+// compareBool orders false before true.
+func compareBool(a bool, b bool) (result int) {
+	switch {
+	case !a && b:
+		result = -1
+	case a && !b:
+		result = 1
+	}
+	return result
+}
+
+// abs32 returns the absolute value of the integer.
+func abs32(value int32) int32 {
+	if value < 0 {
+		return -value
+	}
+	return value
+}
+
+// isCanonical checks the serializer for the canonical option.
+func isCanonical(serializer Serializer) (canonical bool, err error) {
+	if value, has := serializer.Options(CanonicalOption); has {
+		if canonical, err = strconv.ParseBool(value); err != nil {
+			err = MakeErrorWithFormat(ErrInvalidInput, "canonical option in %s: %s", serializer, err)
+		}
+	}
+	return canonical, err
+}
+
+// sortedEntries returns the entries of the collection, the entries of maps and sets are sorted by Compare. Keys are
+// nil for lists and vectors.
+func (elem *collectionElemImpl) sortedEntries() (entries [][2]Element) {
+
+	_ = elem.iterate(func(key Element, value Element) error {
+		entries = append(entries, [2]Element{key, value})
+		return nil
+	})
+
+	if _, isMap := elem.collection.(map[string][2]Element); isMap {
+		sort.SliceStable(entries, func(i, j int) bool {
+			return Compare(entries[i][0], entries[j][0]) < 0
+		})
+	}
+
+	return entries
+}
+
+// entries returns the entries of the collection in the order the serializer writes them: sorted if the serializer is
+// canonical, otherwise in the order they were added.
+func (elem *collectionElemImpl) entries(serializer Serializer) (entries [][2]Element, err error) {
+
+	var canonical bool
+	if canonical, err = isCanonical(serializer); err == nil {
+		if canonical {
+			entries = elem.sortedEntries()
+		} else {
+			err = elem.iterate(func(key Element, value Element) error {
+				entries = append(entries, [2]Element{key, value})
+				return nil
+			})
+		}
+	}
+
+	return entries, err
+}
@@ -0,0 +1,170 @@
+// Copyright 2018-2019 Workiva Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package edn
+
+import (
+	"math"
+	"math/big"
+	"net/url"
+	"time"
+
+	"github.com/Workiva/eva-client-go/test"
+	"github.com/mattrobenolt/gocql/uuid"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Compare in EDN", func() {
+
+	mustParse := func(str string) Element {
+		elem, err := Parse(str)
+		Ω(err).Should(BeNil())
+		return elem
+	}
+
+	Context("ordering elements", func() {
+
+		It("should order the types", func() {
+			ordered := []string{
+				"nil", "false", "true", "-1", "2.5", "3", "\\a", `"a"`, "a", "b/a", ":a", ":b/a",
+				`#inst "2017-12-28T22:20:30Z"`, `#uuid "f81d4fae-7dec-11d0-a765-00a0c91e6bf6"`, `#uri "http://a"`,
+				`#bytes "AQ=="`, "(1)", "[1]", "#{1}", "{1 2}",
+			}
+
+			for i := range ordered {
+				for j := range ordered {
+					expected := compareInt(int64(i), int64(j))
+					Ω(Compare(mustParse(ordered[i]), mustParse(ordered[j]))).Should(Equal(expected), ordered[i]+" <> "+ordered[j])
+				}
+			}
+		})
+
+		It("should order the numbers by value", func() {
+			huge, _ := new(big.Int).SetString("100000000000000000000", 10)
+			ordered := []Element{
+				NewDoubleElement(math.Inf(-1)),
+				bigIntElement(new(big.Int).Neg(huge)),
+				NewIntegerElement(-2),
+				bigDecElement(NewDecimal(big.NewInt(-15), 1)),
+				NewFloatElement(0.5),
+				NewIntegerElement(1),
+				NewDoubleElement(1),
+				bigDecElement(NewDecimal(big.NewInt(2), -2)),
+				bigIntElement(huge),
+				NewDoubleElement(math.Inf(1)),
+				NewDoubleElement(math.NaN()),
+			}
+
+			for i := range ordered {
+				for j := range ordered {
+					Ω(Compare(ordered[i], ordered[j])).Should(Equal(compareInt(int64(i), int64(j))), "%d <> %d", i, j)
+				}
+			}
+		})
+
+		It("should order the primitives of the same type", func() {
+			inst := time.Date(2017, 12, 28, 22, 20, 30, 0, time.UTC)
+			id1, _ := uuid.ParseUUID("f81d4fae-7dec-11d0-a765-00a0c91e6bf6")
+			id2, _ := uuid.ParseUUID("f81d4fae-7dec-11d0-a765-00a0c91e6bf7")
+			uri1, _ := url.Parse("http://a.com")
+			uri2, _ := url.Parse("http://b.com")
+			sym1, _ := NewSymbolElement("b")
+			sym2, _ := NewSymbolElement("a/b")
+			sym3, _ := NewSymbolElement("b/a")
+
+			for _, pair := range [][2]Element{
+				{NewBooleanElement(false), NewBooleanElement(true)},
+				{NewCharacterElement('a'), NewCharacterElement('é')},
+				{NewStringElement("ab"), NewStringElement("b")},
+				{NewInstantElement(inst), NewInstantElement(inst.Add(time.Millisecond))},
+				{NewUUIDElement(id1), NewUUIDElement(id2)},
+				{NewURIElement(uri1), NewURIElement(uri2)},
+				{NewBytesElement([]byte{1}), NewBytesElement([]byte{1, 0})},
+				{sym1, sym2},
+				{sym2, sym3},
+				{mustParse("[2]"), mustParse("[1 1]")},
+				{mustParse("[1 2]"), mustParse("[1 3]")},
+				{mustParse("#{3 1}"), mustParse("#{2 3}")},
+				{mustParse("{:a 2}"), mustParse("{:b 1}")},
+				{mustParse("{:a 1}"), mustParse("{:a 2}")},
+				{mustParse("1"), mustParse("#a 0")},
+				{mustParse("#a 1"), mustParse("#b 0")},
+			} {
+				Ω(Compare(pair[0], pair[1])).Should(Equal(-1), pair[0].String())
+				Ω(Compare(pair[1], pair[0])).Should(Equal(1), pair[1].String())
+				Ω(Compare(pair[0], pair[0])).Should(Equal(0), pair[0].String())
+			}
+		})
+
+		It("should treat equal collections as equal whatever their order", func() {
+			Ω(Compare(mustParse("{:a 1 :b 2}"), mustParse("{:b 2 :a 1}"))).Should(Equal(0))
+			Ω(Compare(mustParse("#{1 2 3}"), mustParse("#{3 2 1}"))).Should(Equal(0))
+		})
+	})
+
+	Context("ordering collections", func() {
+
+		It("should keep maps and sets in the order they were added", func() {
+			elem := mustParse("{:c 1 :a 2 :b 3 \"d\" 4}")
+
+			var keys []string
+			err := elem.(CollectionElement).IterateChildren(func(key Element, _ Element) error {
+				keys = append(keys, key.String())
+				return nil
+			})
+			Ω(err).Should(BeNil())
+			Ω(keys).Should(Equal([]string{":c", ":a", ":b", `"d"`}))
+
+			Ω(elem.String()).Should(BeEquivalentTo(`{:c 1, :a 2, :b 3, "d" 4}`))
+			Ω(mustParse("#{3 1 2}").String()).Should(BeEquivalentTo("#{3 1 2}"))
+		})
+
+		It("should prepend onto the front of maps and sets", func() {
+			elem := mustParse("#{3 1}").(CollectionElement)
+			Ω(elem.Prepend(NewIntegerElement(5), NewIntegerElement(4))).Should(BeNil())
+			Ω(elem.Append(NewIntegerElement(2))).Should(BeNil())
+			Ω(elem.String()).Should(BeEquivalentTo("#{5 4 3 1 2}"))
+		})
+
+		It("should serialize canonically", func() {
+			first := mustParse(`{:c #{3 1 2}, :a {"z" 1, "y" 2}, :b [3 1 2], 1 nil}`)
+			second := mustParse(`{:b [3 1 2], 1 nil, :a {"y" 2, "z" 1}, :c #{2 3 1}}`)
+
+			for mimeType, expected := range map[SerializerMimeType]string{
+				EvaEdnMimeType:  `{1 nil, :a {"y" 2, "z" 1}, :b [3 1 2], :c #{1 2 3}}`,
+				JSONMimeType:    `{"1":null,":a":{"y":2,"z":1},":b":[3,1,2],":c":{"#set":[1,2,3]}}`,
+				TransitMimeType: `["^ ","~i1",null,"~:a",["^ ","y",2,"z",1],"~:b",[3,1,2],"~:c",["~#set",[1,2,3]]]`,
+			} {
+				for _, elem := range []Element{first, second} {
+					str, err := elem.Serialize(mimeType + ";canonical=true")
+					Ω(err).Should(BeNil())
+					Ω(str).Should(BeEquivalentTo(expected), string(mimeType))
+				}
+			}
+		})
+
+		It("should pretty print canonically", func() {
+			str, err := mustParse("{:b 1 :a #{2 1}}").Serialize(EvaEdnMimeType + ";pretty=true,canonical=true")
+			Ω(err).Should(BeNil())
+			Ω(str).Should(BeEquivalentTo("{:a #{1 2}, :b 1}"))
+		})
+
+		It("should not serialize with an invalid canonical option", func() {
+			_, err := mustParse("{:a 1}").Serialize(EvaEdnMimeType + ";canonical=maybe")
+			Ω(err).ShouldNot(BeNil())
+			Ω(err).Should(test.HaveMessage(ErrInvalidInput))
+		})
+	})
+})
@@ -0,0 +1,270 @@
+// Copyright 2018-2019 Workiva Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package edn
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Decoder reads the top level forms from an input stream one at a time.
+type Decoder struct {
+	reader *bufio.Reader
+
+	// buffer holds the stream from the start of the current form.
+	buffer bytes.Buffer
+
+	// offset, line and column give the position in the stream of the start of the buffer.
+	offset int
+	line   int
+	column int
+}
+
+// NewDecoder creates a new decoder that reads from the reader.
+func NewDecoder(reader io.Reader) *Decoder {
+	return &Decoder{
+		reader: bufio.NewReader(reader),
+		line:   1,
+		column: 1,
+	}
+}
+
+// Decode reads the next top level form from the input and returns it as an element. When there are no more forms in
+// the input, io.EOF is returned. Parser errors are returned as a *ParseError with the position in the stream.
+func (dec *Decoder) Decode() (elem Element, err error) {
+
+	var r rune
+	dec.advance()
+	if r, err = dec.skipBlanks(); err == nil {
+		dec.advance()
+		if err = dec.readForm(r, true); err == nil {
+			elem, err = Parse(dec.buffer.String())
+		}
+	}
+
+	return elem, dec.locate(err)
+}
+
+// advance moves the position past the contents of the buffer and empties it.
+func (dec *Decoder) advance() {
+	data := dec.buffer.Bytes()
+	dec.offset += len(data)
+	if index := bytes.LastIndexByte(data, '\n'); index != -1 {
+		dec.line += bytes.Count(data, []byte{'\n'})
+		dec.column = utf8.RuneCount(data[index+1:]) + 1
+	} else {
+		dec.column += utf8.RuneCount(data)
+	}
+	dec.buffer.Reset()
+}
+
+// locate moves the position of a parse error in the buffer to the position in the stream.
+func (dec *Decoder) locate(err error) error {
+	if parseErr, is := err.(*ParseError); is {
+		if parseErr.Line == 1 {
+			parseErr.Column += dec.column - 1
+		}
+		parseErr.Line += dec.line - 1
+		parseErr.Offset += dec.offset
+	}
+	return err
+}
+
+// fail creates the parse error at the offset into the buffer.
+func (dec *Decoder) fail(offset int, details string, cause error) error {
+	return newParseError(ErrParserError, details, dec.buffer.String(), offset, "", cause)
+}
+
+// isDelimiter checks if the rune ends a token.
+func isDelimiter(r rune) bool {
+	switch r {
+	case ',', ';', '"', '(', ')', '[', ']', '{', '}':
+		return true
+	}
+	return unicode.IsSpace(r)
+}
+
+// skipBlanks copies any white space, commas and comments into the buffer and returns the rune after them.
+func (dec *Decoder) skipBlanks() (r rune, err error) {
+	for {
+		if r, _, err = dec.reader.ReadRune(); err != nil {
+			break
+		}
+
+		if r == ';' {
+			var comment string
+			comment, err = dec.reader.ReadString('\n')
+			dec.buffer.WriteRune(r)
+			dec.buffer.WriteString(comment)
+			if err != nil {
+				break
+			}
+		} else if r == ',' || unicode.IsSpace(r) {
+			dec.buffer.WriteRune(r)
+		} else {
+			break
+		}
+	}
+
+	return r, err
+}
+
+// readNext copies the blanks and the form after them into the buffer.
+func (dec *Decoder) readNext() (err error) {
+	var r rune
+	if r, err = dec.skipBlanks(); err == nil {
+		err = dec.readForm(r, false)
+	}
+	return dec.unexpectedEOF(err)
+}
+
+// readForm copies the form starting with the rune into the buffer. Strings, characters and comments are copied as is
+// so that the brackets within them do not affect the nesting. A discarded form is read along with the form after it,
+// at the top level the input may end in place of that form.
+func (dec *Decoder) readForm(r rune, top bool) (err error) {
+
+	if r == '#' && dec.nextIs(DiscardPrefix[1]) {
+		_, _ = dec.reader.ReadByte()
+		dec.buffer.WriteString(DiscardPrefix)
+
+		if err = dec.readNext(); err == nil {
+			if !top {
+				err = dec.readNext()
+			} else if r, err = dec.skipBlanks(); err == nil {
+				err = dec.readForm(r, true)
+			}
+		}
+		return err
+	}
+
+	depth := 0
+	for {
+		dec.buffer.WriteRune(r)
+
+		switch {
+		case r == '"':
+			err = dec.readString()
+		case r == '\\':
+			err = dec.readToken(true)
+		case r == ';':
+			var comment string
+			comment, err = dec.reader.ReadString('\n')
+			dec.buffer.WriteString(comment)
+		case r == '(' || r == '[' || r == '{':
+			depth++
+		case r == ')' || r == ']' || r == '}':
+			depth--
+		case r == '#' && dec.nextIs(SymbolicPrefix[1]):
+			// symbolic values are complete tokens.
+			err = dec.readToken(false)
+		case r == '#':
+			// tags and sets are prefixes, the form is not complete until the tagged element has been read.
+			if err = dec.readToken(false); err == nil {
+				if bytes.HasSuffix(dec.buffer.Bytes(), setStart) {
+					depth++
+				} else if depth == 0 {
+					return dec.readNext()
+				}
+			}
+		case unicode.IsSpace(r) || r == ',':
+		default:
+			err = dec.readToken(false)
+		}
+
+		if err != nil || depth <= 0 {
+			break
+		}
+
+		if r, _, err = dec.reader.ReadRune(); err != nil {
+			break
+		}
+	}
+
+	if err == nil && depth < 0 {
+		err = dec.fail(dec.buffer.Len()-utf8.RuneLen(r), "Unexpected closing delimiter", nil)
+	}
+
+	return dec.unexpectedEOF(err)
+}
+
+// setStart and tagStart are the prefixes of sets and tags as bytes.
+var (
+	setStart = []byte(SetStartLiteral)
+	tagStart = []byte(TagPrefix)
+)
+
+// nextIs checks if the next byte in the reader is the one given without consuming it.
+func (dec *Decoder) nextIs(b byte) bool {
+	next, err := dec.reader.Peek(1)
+	return err == nil && next[0] == b
+}
+
+// unexpectedEOF converts an end of input found part way through a form into a parser error at the end of the input.
+func (dec *Decoder) unexpectedEOF(err error) error {
+	if err == io.EOF {
+		err = dec.fail(dec.buffer.Len(), "Unexpected end of input", io.ErrUnexpectedEOF)
+	}
+	return err
+}
+
+// readString copies the rest of a string literal into the buffer.
+func (dec *Decoder) readString() (err error) {
+
+	var r rune
+	escaped := false
+	for r, _, err = dec.reader.ReadRune(); err == nil; r, _, err = dec.reader.ReadRune() {
+		dec.buffer.WriteRune(r)
+
+		if escaped {
+			escaped = false
+		} else if r == '\\' {
+			escaped = true
+		} else if r == '"' {
+			break
+		}
+	}
+
+	return err
+}
+
+// readToken copies the rest of the token into the buffer. If the token is a character the first rune is always part
+// of the token, even if it is a delimiter.
+func (dec *Decoder) readToken(isCharacter bool) (err error) {
+
+	var r rune
+	for r, _, err = dec.reader.ReadRune(); err == nil; r, _, err = dec.reader.ReadRune() {
+		if !isCharacter && isDelimiter(r) {
+			if r == '{' && bytes.HasSuffix(dec.buffer.Bytes(), tagStart) {
+				dec.buffer.WriteRune(r)
+			} else {
+				err = dec.reader.UnreadRune()
+			}
+			break
+		}
+
+		isCharacter = false
+		dec.buffer.WriteRune(r)
+	}
+
+	// the end of the stream also ends the token.
+	if err == io.EOF {
+		err = nil
+	}
+
+	return err
+}
@@ -0,0 +1,137 @@
+// Copyright 2018-2019 Workiva Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package edn
+
+import (
+	"errors"
+	"io"
+	"strings"
+
+	"github.com/Workiva/eva-client-go/test"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Decoder in EDN", func() {
+
+	decodeAll := func(data string) (results []string, err error) {
+		dec := NewDecoder(strings.NewReader(data))
+		for {
+			var elem Element
+			if elem, err = dec.Decode(); err != nil {
+				break
+			}
+			results = append(results, elem.String())
+		}
+
+		if err == io.EOF {
+			err = nil
+		}
+
+		return results, err
+	}
+
+	It("should return EOF for empty input", func() {
+		results, err := decodeAll(" , ; just a comment\n  ")
+		Ω(err).Should(BeNil())
+		Ω(results).Should(BeEmpty())
+	})
+
+	It("should decode multiple top level forms", func() {
+		results, err := decodeAll(`1 :foo "bar [" [1 2 (3)] ; comment ]
+                                   {:a #{1}} \a nil #tag/val [4] #{:b} sym`)
+		Ω(err).Should(BeNil())
+		Ω(results).Should(BeEquivalentTo([]string{
+			"1",
+			":foo",
+			`"bar ["`,
+			"[1 2 (3)]",
+			"{:a #{1}}",
+			`\a`,
+			"nil",
+			"#tag/val [4]",
+			"#{:b}",
+			"sym",
+		}))
+	})
+
+	It("should decode forms with no whitespace between them", func() {
+		results, err := decodeAll(`[1]{:a "b"}(2)"s"`)
+		Ω(err).Should(BeNil())
+		Ω(results).Should(BeEquivalentTo([]string{"[1]", `{:a "b"}`, "(2)", `"s"`}))
+	})
+
+	It("should keep comments and strings from breaking the nesting", func() {
+		results, err := decodeAll("[1 ; ] ignored\n \"]\" \\x 2]")
+		Ω(err).Should(BeNil())
+		Ω(results).Should(BeEquivalentTo([]string{`[1 "]" \x 2]`}))
+	})
+
+	It("should error when the input ends in the middle of a form", func() {
+		for _, data := range []string{"[1 2", `"open`, "#tag", "{:a [1]"} {
+			_, err := decodeAll(data)
+			Ω(err).Should(test.HaveMessage(ErrParserError), data)
+		}
+	})
+
+	It("should drop the discarded forms", func() {
+		results, err := decodeAll(`#_ [1 2] 3 #_#_ 4 5 6 #_x [#_ 7 8] #tag #_ 9 10 #_ 11`)
+		Ω(err).Should(BeNil())
+		Ω(results).Should(BeEquivalentTo([]string{"3", "6", "[8]", "#tag 10"}))
+
+		for _, data := range []string{"#_", "1 #_ ", "#tag #_ 1"} {
+			_, err := decodeAll(data)
+			Ω(err).Should(test.HaveMessage(ErrParserError), data)
+		}
+	})
+
+	It("should error on unbalanced closing delimiters", func() {
+		_, err := decodeAll("1 ]")
+		Ω(err).Should(test.HaveMessage(ErrParserError))
+		Ω(err).Should(BeAssignableToTypeOf(&ParseError{}))
+		Ω(err.(*ParseError).Offset).Should(BeEquivalentTo(2))
+		Ω(err.(*ParseError).Column).Should(BeEquivalentTo(3))
+	})
+
+	It("should report the position of errors in the stream", func() {
+		_, err := decodeAll("1\n[2 3]\n; comment\n{:a 1} \"é\" [:b :c/]")
+		Ω(err).Should(test.HaveMessage(ErrInvalidKeyword))
+		parseErr, is := err.(*ParseError)
+		Ω(is).Should(BeTrue())
+		Ω(parseErr.Line).Should(BeEquivalentTo(4))
+		Ω(parseErr.Column).Should(BeEquivalentTo(16))
+		Ω(parseErr.Offset).Should(BeEquivalentTo(34))
+	})
+
+	It("should report the end of the stream when the input ends in the middle of a form", func() {
+		_, err := decodeAll("1\n [2\n 3")
+		parseErr, is := err.(*ParseError)
+		Ω(is).Should(BeTrue())
+		Ω(parseErr.Line).Should(BeEquivalentTo(3))
+		Ω(parseErr.Column).Should(BeEquivalentTo(3))
+		Ω(parseErr.Offset).Should(BeEquivalentTo(8))
+		Ω(errors.Is(err, io.ErrUnexpectedEOF)).Should(BeTrue())
+	})
+
+	It("should continue to report parser errors", func() {
+		dec := NewDecoder(strings.NewReader(`[1 1 2] {:a}`))
+		elem, err := dec.Decode()
+		Ω(err).Should(BeNil())
+		Ω(elem.ElementType()).Should(BeEquivalentTo(VectorType))
+
+		_, err = dec.Decode()
+		Ω(err).ShouldNot(BeNil())
+	})
+})
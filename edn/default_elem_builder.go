@@ -14,11 +14,27 @@
 
 package edn
 
-import "reflect"
+import (
+	"io"
+	"reflect"
+	"strings"
+)
 
 // stringerFunc defines the mechanism to stringify the element.
 type stringerFunc func(Serializer, string, interface{}) (string, error)
 
+// writerFunc defines the mechanism to stream the element onto a writer.
+type writerFunc func(io.Writer, Serializer, string, interface{}) error
+
+// stringerFromWriter creates a stringer that collects the output of the writer.
+func stringerFromWriter(writer writerFunc) stringerFunc {
+	return func(serializer Serializer, tag string, value interface{}) (string, error) {
+		var builder strings.Builder
+		err := writer(&builder, serializer, tag, value)
+		return builder.String(), err
+	}
+}
+
 // equalityFunc defines the mechanism testing equality
 type equalityFunc func(left, right Element) bool
 
@@ -0,0 +1,80 @@
+// Copyright 2018-2019 Workiva Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package edn
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// symbolicValues are the doubles that can only be written as symbolic values.
+var symbolicValues = map[string]float64{
+	InfLiteral:         math.Inf(1),
+	NegativeInfLiteral: math.Inf(-1),
+	NaNLiteral:         math.NaN(),
+}
+
+// init will add the element factory to the collection of factories
+func initDouble(lexer Lexer) (err error) {
+	if err = addElementTypeFactory(DoubleType, func(input interface{}) (elem Element, e error) {
+		if v, ok := input.(float64); ok {
+			elem = NewDoubleElement(v)
+		} else {
+			e = MakeError(ErrInvalidInput, input)
+		}
+		return elem, e
+	}); err == nil {
+
+		// float literals have the semantics of a double.
+		lexer.AddPattern(FloatPrimitive, floatPattern, func(tag string, tokenValue string) (el Element, e error) {
+
+			// M suffixed literals are exact decimals.
+			var v float64
+			if strings.HasSuffix(tokenValue, BigDecSuffix) {
+				el, e = parseBigDec(tokenValue)
+			} else if v, e = strconv.ParseFloat(tokenValue, 64); e == nil {
+				el = NewDoubleElement(v)
+			}
+
+			if e == nil {
+				e = el.SetTag(tag)
+			}
+
+			return el, e
+		})
+
+		for literal, value := range symbolicValues {
+			value := value
+			lexer.AddPattern(LiteralPrimitive, literal, func(tag string, tokenValue string) (Element, error) {
+				elem := NewDoubleElement(value)
+				return elem, elem.SetTag(tag)
+			})
+		}
+	}
+
+	return err
+}
+
+// NewDoubleElement creates a new double-precision float point element or an error.
+func NewDoubleElement(value float64) (elem Element) {
+
+	var err error
+	if elem, err = baseFactory().make(value, DoubleType, floatStringer(64)); err != nil {
+		panic(err)
+	}
+
+	return elem
+}
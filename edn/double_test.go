@@ -0,0 +1,186 @@
+// Copyright 2018-2019 Workiva Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package edn
+
+import (
+	"math"
+	"strings"
+
+	"github.com/Workiva/eva-client-go/test"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Double in EDN", func() {
+	Context("", func() {
+
+		It("should initialize without issue", func() {
+			lexer, err := newLexer()
+			Ω(err).Should(BeNil())
+
+			delete(typeFactories, DoubleType)
+			err = initDouble(lexer)
+			Ω(err).Should(BeNil())
+			_, has := typeFactories[DoubleType]
+			Ω(has).Should(BeTrue())
+
+			err = initDouble(lexer)
+			Ω(err).ShouldNot(BeNil())
+			Ω(err).Should(test.HaveMessage(ErrInvalidFactory))
+		})
+
+		It("should create elements from the factory", func() {
+			v := float64(1.234)
+
+			elem, err := typeFactories[DoubleType](v)
+			Ω(err).Should(BeNil())
+			Ω(elem.ElementType()).Should(BeEquivalentTo(DoubleType))
+			Ω(elem.Value()).Should(BeEquivalentTo(v))
+		})
+
+		It("should not create elements from the factory if the input is not a the right type", func() {
+			v := "foo"
+
+			elem, err := typeFactories[DoubleType](v)
+			Ω(err).ShouldNot(BeNil())
+			Ω(err).Should(test.HaveMessage(ErrInvalidInput))
+			Ω(elem).Should(BeNil())
+		})
+
+		It("should panic if the base factory errors.", func() {
+			origFac := baseFactory
+			baseFactory = func() elementFactory { return &breakerFactory{} }
+
+			wrapper := func() {
+				NewDoubleElement(123.4)
+			}
+
+			Ω(wrapper).Should(Panic())
+			baseFactory = origFac
+		})
+	})
+
+	Context("with the default marshaller", func() {
+
+		testValue := float64(12345.67)
+
+		It("should create a double value with no error", func() {
+			elem := NewDoubleElement(testValue)
+			Ω(elem).ShouldNot(BeNil())
+			Ω(elem.ElementType()).Should(BeEquivalentTo(DoubleType))
+			Ω(elem.Value()).Should(BeEquivalentTo(testValue))
+		})
+
+		It("should serialize the double in the shortest form that reads back", func() {
+			tenth := 0.1
+			for value, expected := range map[float64]string{
+				testValue:        "12345.67",
+				0.1:              "0.1",
+				tenth + 0.2:      "0.30000000000000004",
+				1:                "1.0",
+				-0.5:             "-0.5",
+				1e21:             "1e+21",
+				1e-7:             "1e-07",
+				math.MaxFloat64:  "1.7976931348623157e+308",
+				math.Inf(1):      "##Inf",
+				math.Inf(-1):     "##-Inf",
+				float64(1 << 53): "9.007199254740992e+15",
+			} {
+				edn, err := NewDoubleElement(value).Serialize(EvaEdnMimeType)
+				Ω(err).Should(BeNil())
+				Ω(edn).Should(BeEquivalentTo(expected))
+
+				elem, err := Parse(edn)
+				Ω(err).Should(BeNil())
+				Ω(elem.ElementType()).Should(BeEquivalentTo(DoubleType))
+				Ω(elem.Value()).Should(BeEquivalentTo(value))
+			}
+		})
+
+		It("should serialize not a number", func() {
+			edn, err := NewDoubleElement(math.NaN()).Serialize(EvaEdnMimeType)
+			Ω(err).Should(BeNil())
+			Ω(edn).Should(BeEquivalentTo("##NaN"))
+		})
+
+		It("should not serialize the symbolic values as JSON", func() {
+			_, err := NewDoubleElement(math.Inf(-1)).Serialize(JSONMimeType)
+			Ω(err).ShouldNot(BeNil())
+			Ω(err).Should(test.HaveMessage(ErrInvalidInput))
+		})
+
+		It("should not serialize with an unknown serializer", func() {
+			elem := NewDoubleElement(testValue)
+			Ω(elem).ShouldNot(BeNil())
+
+			_, err := elem.Serialize(SerializerMimeType("InvalidSerializer"))
+			Ω(err).ShouldNot(BeNil())
+			Ω(err).Should(test.HaveMessage(ErrUnknownMimeType))
+		})
+	})
+
+	Context("Parsing", func() {
+		runParserTests(DoubleType,
+			&testDefinition{"0.0", 0.0},
+			&testDefinition{"+0.0", 0.0},
+			&testDefinition{"-0.0", 0.0},
+			&testDefinition{"1.0", 1.0},
+			&testDefinition{"-1.0", -1.0},
+			&testDefinition{"1234.0", 1234.0},
+			&testDefinition{"12.340", 12.34},
+			&testDefinition{"12.34", 12.34},
+			&testDefinition{"1234E-2", 12.34},
+			&testDefinition{"1.234E1", 12.34},
+			&testDefinition{"12.34E0", 12.34},
+			&testDefinition{"##Inf", math.Inf(1)},
+			&testDefinition{"##-Inf", math.Inf(-1)},
+		)
+
+		It("should parse not a number", func() {
+			elem, err := Parse("##NaN")
+			Ω(err).Should(BeNil())
+			Ω(elem.ElementType()).Should(BeEquivalentTo(DoubleType))
+			Ω(math.IsNaN(elem.Value().(float64))).Should(BeTrue())
+		})
+
+		It("should parse tagged and nested symbolic values", func() {
+			elem, err := Parse("[##Inf #my/tag ##-Inf #_ ##NaN {:a ##NaN}]")
+			Ω(err).Should(BeNil())
+
+			edn, err := elem.Serialize(EvaEdnMimeType)
+			Ω(err).Should(BeNil())
+			Ω(edn).Should(BeEquivalentTo("[##Inf #my/tag ##-Inf {:a ##NaN}]"))
+		})
+
+		It("should not parse unknown symbolic values", func() {
+			_, err := Parse("##Infinity")
+			Ω(err).ShouldNot(BeNil())
+			Ω(err).Should(test.HaveMessage(ErrParserError))
+		})
+
+		It("should decode symbolic values from a stream", func() {
+			dec := NewDecoder(strings.NewReader("##Inf #my/tag ##NaN [##-Inf]"))
+
+			var forms []string
+			for elem, err := dec.Decode(); err == nil; elem, err = dec.Decode() {
+				edn, e := elem.Serialize(EvaEdnMimeType)
+				Ω(e).Should(BeNil())
+				forms = append(forms, edn)
+			}
+
+			Ω(forms).Should(Equal([]string{"##Inf", "#my/tag ##NaN", "[##-Inf]"}))
+		})
+	})
+})
@@ -15,6 +15,10 @@
 package edn
 
 import (
+	"encoding/json"
+	"io"
+	"math/big"
+	"net/url"
 	"time"
 
 	"github.com/mattrobenolt/gocql/uuid"
@@ -27,11 +31,15 @@ const (
 
 	// TagPrefix defines the prefix for tags.
 	TagPrefix = "#"
+
+	// DiscardPrefix defines the prefix that discards the next element when parsing.
+	DiscardPrefix = "#_"
 )
 
 // Element defines the interface for EDN elements.
 type Element interface {
 	Serializable
+	json.Marshaler
 
 	// ElementType returns the current type of this element.
 	ElementType() ElementType
@@ -50,6 +58,9 @@ type Element interface {
 
 	// Equals checks if the input element is equal to this element.
 	Equals(e Element) (result bool)
+
+	// SerializeTo writes the serialized element onto the writer.
+	SerializeTo(writer io.Writer, serializer Serializer) error
 }
 
 // stereotypePrimitive returns the cleaned value and stereotype, or it returns an error.
@@ -68,11 +79,15 @@ func stereotypePrimitive(value interface{}) (_ interface{}, stereotype ElementTy
 		value = v
 	case int64:
 		stereotype = IntegerType
+	case *big.Int:
+		stereotype = BigIntType
+	case *Decimal:
+		stereotype = BigDecType
 	case float32:
 		stereotype = FloatType
 		value = float64(v)
 	case float64:
-		stereotype = FloatType
+		stereotype = DoubleType
 	case string:
 		if v == "nil" {
 			stereotype = NilType
@@ -87,6 +102,10 @@ func stereotypePrimitive(value interface{}) (_ interface{}, stereotype ElementTy
 		stereotype = InstantType
 	case uuid.UUID:
 		stereotype = UUIDType
+	case *url.URL:
+		stereotype = URIType
+	case []byte:
+		stereotype = BytesType
 	default:
 		err = MakeErrorWithFormat(ErrUnknownMimeType, "[%T]: %#v", v, v)
 	}
@@ -100,8 +119,11 @@ func NewPrimitiveElement(value interface{}) (elem Element, err error) {
 	if value == nil {
 		elem = NewNilElement()
 	} else {
-		var is bool
+		var is, has bool
 		if elem, is = value.(Element); !is {
+			if elem, has, err = writeTagged(value); has {
+				return elem, err
+			}
 
 			var stereotype ElementType
 			var val interface{}
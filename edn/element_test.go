@@ -183,7 +183,7 @@ var _ = Describe("Elements in EDN", func() {
 			Ω(IsPrimitive(float64(1.2))).Should(BeTrue())
 			Ω(err).Should(BeNil())
 			Ω(elem).ShouldNot(BeNil())
-			Ω(elem.ElementType()).Should(BeEquivalentTo(FloatType))
+			Ω(elem.ElementType()).Should(BeEquivalentTo(DoubleType))
 			Ω(elem.Value()).Should(BeEquivalentTo(float64(1.2)))
 		})
 
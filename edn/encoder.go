@@ -0,0 +1,62 @@
+// Copyright 2018-2019 Workiva Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package edn
+
+import (
+	"bufio"
+	"io"
+)
+
+// Encoder writes serialized elements onto an output stream.
+type Encoder struct {
+	writer     *bufio.Writer
+	serializer Serializer
+}
+
+// NewEncoder creates a new encoder that writes to the writer with the default serializer.
+func NewEncoder(writer io.Writer) *Encoder {
+	return &Encoder{
+		writer:     bufio.NewWriter(writer),
+		serializer: DefaultMimeType,
+	}
+}
+
+// SetSerializer changes the serializer used for the following calls to Encode.
+func (enc *Encoder) SetSerializer(serializer Serializer) {
+	enc.serializer = serializer
+}
+
+// Encode writes the serialized item followed by a newline onto the stream. Elements are streamed directly onto the
+// writer, other serializable items are written in one piece.
+func (enc *Encoder) Encode(item Serializable) (err error) {
+
+	switch v := item.(type) {
+	case Element:
+		err = v.SerializeTo(enc.writer, enc.serializer)
+	default:
+		var composition string
+		if composition, err = item.Serialize(enc.serializer); err == nil {
+			_, err = enc.writer.WriteString(composition)
+		}
+	}
+
+	if err == nil {
+		if err = enc.writer.WriteByte('\n'); err == nil {
+			err = enc.writer.Flush()
+		}
+	}
+
+	return err
+}
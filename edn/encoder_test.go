@@ -0,0 +1,103 @@
+// Copyright 2018-2019 Workiva Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package edn
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+
+	"github.com/Workiva/eva-client-go/test"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type failingWriter struct {
+	after int
+}
+
+func (writer *failingWriter) Write(p []byte) (n int, err error) {
+	if writer.after -= len(p); writer.after < 0 {
+		err = errors.New("write failed")
+	}
+	return len(p), err
+}
+
+var _ = Describe("Encoder in EDN", func() {
+
+	It("should write each element on its own line", func() {
+		var buffer bytes.Buffer
+		enc := NewEncoder(&buffer)
+
+		vector, err := NewVector(NewIntegerElement(1), NewStringElement("two"))
+		Ω(err).Should(BeNil())
+		err = vector.SetTag("my/tag")
+		Ω(err).Should(BeNil())
+
+		keyword, err := NewKeywordElement("foo/bar")
+		Ω(err).Should(BeNil())
+
+		Ω(enc.Encode(vector)).Should(BeNil())
+		Ω(enc.Encode(keyword)).Should(BeNil())
+		Ω(buffer.String()).Should(BeEquivalentTo("#my/tag [1 \"two\"]\n:foo/bar\n"))
+	})
+
+	It("should match the serialized string for large collections", func() {
+		var children []Element
+		for i := 0; i < 10000; i++ {
+			key, err := NewKeywordElement("db/id")
+			Ω(err).Should(BeNil())
+
+			var m CollectionElement
+			m, err = NewMap(&pairImpl{key, NewIntegerElement(int64(i))})
+			Ω(err).Should(BeNil())
+			children = append(children, m)
+		}
+
+		vector, err := NewVector(children...)
+		Ω(err).Should(BeNil())
+
+		var buffer bytes.Buffer
+		Ω(NewEncoder(&buffer).Encode(vector)).Should(BeNil())
+
+		str, err := vector.Serialize(EvaEdnMimeType)
+		Ω(err).Should(BeNil())
+		Ω(buffer.String()).Should(BeEquivalentTo(str + "\n"))
+		Ω(strings.Count(str, ":db/id")).Should(BeEquivalentTo(10000))
+	})
+
+	It("should report the serializer errors", func() {
+		var buffer bytes.Buffer
+		enc := NewEncoder(&buffer)
+		enc.SetSerializer(SerializerMimeType("InvalidSerializer"))
+
+		vector, err := NewVector(NewIntegerElement(1))
+		Ω(err).Should(BeNil())
+
+		err = enc.Encode(vector)
+		Ω(err).Should(test.HaveMessage(ErrUnknownMimeType))
+
+		err = enc.Encode(NewIntegerElement(1))
+		Ω(err).Should(test.HaveMessage(ErrUnknownMimeType))
+	})
+
+	It("should report the writer errors", func() {
+		vector, err := NewVector(NewStringElement(strings.Repeat("a", 8192)))
+		Ω(err).Should(BeNil())
+
+		err = NewEncoder(&failingWriter{}).Encode(vector)
+		Ω(err).ShouldNot(BeNil())
+	})
+})
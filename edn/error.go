@@ -108,10 +108,11 @@ func MakeError(message ErrorMessage, details interface{}) (err *Error) {
 
 // IsEquivalent checks if the errors are equivalent.
 func (em ErrorMessage) IsEquivalent(err error) (eq bool) {
-	if err != nil {
-		if myErr, is := err.(*Error); is {
-			eq = em == myErr.message
-		}
+	switch myErr := err.(type) {
+	case *Error:
+		eq = em == myErr.message
+	case *ParseError:
+		eq = em == myErr.message
 	}
 
 	return eq
@@ -15,55 +15,122 @@
 package edn
 
 import (
+	"math"
 	"strconv"
 	"strings"
 )
 
-// init will add the element factory to the collection of factories
-func initFloat(lexer Lexer) (err error) {
-	if err = addElementTypeFactory(FloatType, func(input interface{}) (elem Element, e error) {
-		if v, ok := input.(float64); ok {
-			elem = NewFloatElement(v)
-		} else {
-			e = MakeError(ErrInvalidInput, input)
-		}
-		return elem, e
-	}); err == nil {
-		lexer.AddPattern(FloatPrimitive, "[-+]?(0|[1-9][0-9]*)(\\.[0-9]*)?([eE][-+]?[0-9]+)?M?", func(tag string, tokenValue string) (el Element, e error) {
+const (
 
-			if strings.HasSuffix(tokenValue, "M") {
-				tokenValue = strings.TrimSuffix(tokenValue, "M")
-			}
+	// floatPattern is the lexer pattern for floats.
+	floatPattern = "[-+]?(0|[1-9][0-9]*)(\\.[0-9]*)?([eE][-+]?[0-9]+)?M?"
 
-			var v float64
-			if v, e = strconv.ParseFloat(tokenValue, 64); e == nil {
-				el = NewFloatElement(v)
-				e = el.SetTag(tag)
-			}
+	// SymbolicPrefix defines the prefix of the symbolic values.
+	SymbolicPrefix = "##"
 
-			return el, e
-		})
+	// InfLiteral defines the symbolic value for positive infinity.
+	InfLiteral = SymbolicPrefix + "Inf"
+
+	// NegativeInfLiteral defines the symbolic value for negative infinity.
+	NegativeInfLiteral = SymbolicPrefix + "-Inf"
+
+	// NaNLiteral defines the symbolic value for not a number.
+	NaNLiteral = SymbolicPrefix + "NaN"
+)
+
+// floatTokenMatcher is a hand-written equivalent of floatPattern.
+func floatTokenMatcher(token string) bool {
+	i := integerPrefix(token)
+	if i == 0 {
+		return false
 	}
 
-	return err
+	if i < len(token) && token[i] == '.' {
+		for i++; i < len(token) && isDigit(token[i]); i++ {
+		}
+	}
+
+	if i < len(token) && (token[i] == 'e' || token[i] == 'E') {
+		if i++; i < len(token) && (token[i] == '-' || token[i] == '+') {
+			i++
+		}
+
+		digits := i
+		for ; i < len(token) && isDigit(token[i]); i++ {
+		}
+		if digits == i {
+			return false
+		}
+	}
+
+	return i == len(token) || i == len(token)-1 && token[i:] == BigDecSuffix
 }
 
-// NewFloatElement creates a new float point element or an error.
-func NewFloatElement(value float64) (elem Element) {
+// init will add the element factory to the collection of factories
+func initFloat(_ Lexer) error {
+	return addElementTypeFactory(FloatType, func(input interface{}) (elem Element, err error) {
+		switch v := input.(type) {
+		case float32:
+			elem = NewFloatElement(float64(v))
+		case float64:
+			elem = NewFloatElement(v)
+		default:
+			err = MakeError(ErrInvalidInput, input)
+		}
+		return elem, err
+	})
+}
 
-	var err error
-	if elem, err = baseFactory().make(value, FloatType, func(serializer Serializer, tag string, value interface{}) (out string, e error) {
+// formatFloat writes the float in the shortest form that reads back to the same value with the bit size. The result
+// always reads as a float, never as an integer, and infinity and not a number use the symbolic values.
+func formatFloat(value float64, bitSize int) (out string) {
+	switch {
+	case math.IsNaN(value):
+		out = NaNLiteral
+	case math.IsInf(value, 1):
+		out = InfLiteral
+	case math.IsInf(value, -1):
+		out = NegativeInfLiteral
+	default:
+		if out = strconv.FormatFloat(value, 'g', -1, bitSize); !strings.ContainsAny(out, ".e") {
+			out += ".0"
+		}
+	}
+
+	return out
+}
+
+// floatStringer returns the stringer for floating point elements of the bit size.
+func floatStringer(bitSize int) stringerFunc {
+	return func(serializer Serializer, tag string, value interface{}) (out string, e error) {
 		switch serializer.MimeType() {
 		case EvaEdnMimeType:
 			if len(tag) > 0 {
 				out = TagPrefix + tag + " "
 			}
-			out += strconv.FormatFloat(value.(float64), 'E', -1, 64)
+			out += formatFloat(value.(float64), bitSize)
+		case JSONMimeType:
+			// JSON has no representation for infinity or not a number.
+			if f := value.(float64); math.IsInf(f, 0) || math.IsNaN(f) {
+				e = MakeErrorWithFormat(ErrInvalidInput, "%v can not be represented in JSON", f)
+			} else {
+				out = jsonTagged(tag, formatFloat(f, bitSize))
+			}
+		case TransitMimeType:
+			out, e = transitString(tag, value)
 		default:
 			e = MakeError(ErrUnknownMimeType, serializer.MimeType())
 		}
 		return out, e
-	}); err != nil {
+	}
+}
+
+// NewFloatElement creates a new single-precision float point element or an error. The value is written with the
+// precision of a 32-bit float.
+func NewFloatElement(value float64) (elem Element) {
+
+	var err error
+	if elem, err = baseFactory().make(value, FloatType, floatStringer(32)); err != nil {
 		panic(err)
 	}
 
@@ -15,6 +15,8 @@
 package edn
 
 import (
+	"math"
+
 	"github.com/Workiva/eva-client-go/test"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -47,6 +49,13 @@ var _ = Describe("Float in EDN", func() {
 			Ω(elem.Value()).Should(BeEquivalentTo(v))
 		})
 
+		It("should create elements from the factory with a float32", func() {
+			elem, err := typeFactories[FloatType](float32(1.5))
+			Ω(err).Should(BeNil())
+			Ω(elem.ElementType()).Should(BeEquivalentTo(FloatType))
+			Ω(elem.Value()).Should(BeEquivalentTo(float64(1.5)))
+		})
+
 		It("should not create elements from the factory if the input is not a the right type", func() {
 			v := "foo"
 
@@ -86,7 +95,27 @@ var _ = Describe("Float in EDN", func() {
 
 			edn, err := elem.Serialize(EvaEdnMimeType)
 			Ω(err).Should(BeNil())
-			Ω(edn).Should(BeEquivalentTo("1.234567E+04"))
+			Ω(edn).Should(BeEquivalentTo("12345.67"))
+		})
+
+		It("should serialize the float with the precision of a 32-bit float", func() {
+			for value, expected := range map[float64]string{
+				0.1:          "0.1",
+				1:            "1.0",
+				-100000:      "-100000.0",
+				1.0 / 3:      "0.33333334",
+				1e30:         "1e+30",
+				math.Inf(1):  "##Inf",
+				math.Inf(-1): "##-Inf",
+			} {
+				edn, err := NewFloatElement(value).Serialize(EvaEdnMimeType)
+				Ω(err).Should(BeNil())
+				Ω(edn).Should(BeEquivalentTo(expected))
+			}
+
+			edn, err := NewFloatElement(math.NaN()).Serialize(EvaEdnMimeType)
+			Ω(err).Should(BeNil())
+			Ω(edn).Should(BeEquivalentTo("##NaN"))
 		})
 
 		It("should serialize the float without an issue", func() {
@@ -98,25 +127,4 @@ var _ = Describe("Float in EDN", func() {
 			Ω(err).Should(test.HaveMessage(ErrUnknownMimeType))
 		})
 	})
-
-	Context("Parsing", func() {
-		runParserTests(FloatType,
-			&testDefinition{"0.0", 0.0},
-			&testDefinition{"+0.0", 0.0},
-			&testDefinition{"-0.0", 0.0},
-			&testDefinition{"1.0", 1.0},
-			&testDefinition{"-1.0", -1.0},
-			&testDefinition{"1234.0", 1234.0},
-			&testDefinition{"12.340", 12.34},
-			&testDefinition{"12.34", 12.34},
-			&testDefinition{"0M", 0.0},
-			&testDefinition{"+0M", 0.0},
-			&testDefinition{"-0M", 0.0},
-			&testDefinition{"1M", 1.0},
-			&testDefinition{"-1M", -1.0},
-			&testDefinition{"1234E-2", 12.34},
-			&testDefinition{"1.234E1", 12.34},
-			&testDefinition{"12.34E0", 12.34},
-		)
-	})
 })
@@ -15,6 +15,8 @@
 package edn
 
 import (
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -22,12 +24,20 @@ const (
 
 	// InstantElementTag defines the instant tag value.
 	InstantElementTag = "inst"
+
+	// PrecisionOption is the serializer option for the number of fractional second digits written for instants, e.g.
+	// application/vnd.eva+edn;precision=3. Without it instants are written with as many digits as needed to be
+	// lossless.
+	PrecisionOption = "precision"
+
+	// maxPrecision is the number of fractional second digits needed for nanoseconds.
+	maxPrecision = 9
 )
 
 // instStringProcessor used the string processor but will accurately create the instances.
 func instStringProcessor(tokenValue string) (el Element, e error) {
 	var t time.Time
-	if t, e = time.Parse(time.RFC3339, tokenValue); e == nil {
+	if t, e = time.Parse(time.RFC3339Nano, tokenValue); e == nil {
 		el = NewInstantElement(t)
 	}
 
@@ -56,7 +66,17 @@ func NewInstantElement(value time.Time) (elem Element) {
 			if len(tag) > 0 {
 				out = TagPrefix + tag + " "
 			}
-			out += value.(time.Time).Format(time.RFC3339)
+			var inst string
+			if inst, e = formatInstant(serializer, value.(time.Time)); e == nil {
+				out += quoteString(inst)
+			}
+		case JSONMimeType:
+			var inst string
+			if inst, e = formatInstant(serializer, value.(time.Time)); e == nil {
+				out = jsonTagged(tag, jsonQuote(inst))
+			}
+		case TransitMimeType:
+			out, e = transitString(tag, value)
 		default:
 			e = MakeError(ErrUnknownMimeType, serializer.MimeType())
 		}
@@ -70,3 +90,32 @@ func NewInstantElement(value time.Time) (elem Element) {
 
 	return elem
 }
+
+// formatInstant writes the instant as RFC 3339 keeping its offset. The fractional seconds are written to the precision
+// requested by the serializer, or with as many digits as needed if there is none.
+func formatInstant(serializer Serializer, value time.Time) (out string, err error) {
+
+	layout := time.RFC3339Nano
+	if option, has := serializer.Options(PrecisionOption); has {
+		var digits int
+		if digits, err = strconv.Atoi(option); err == nil && (digits < 0 || digits > maxPrecision) {
+			err = MakeErrorWithFormat(ErrInvalidInput, "precision must be between 0 and %d", maxPrecision)
+		}
+
+		if err == nil {
+			layout = "2006-01-02T15:04:05"
+			if digits > 0 {
+				layout += "." + strings.Repeat("0", digits)
+			}
+			layout += "Z07:00"
+		} else {
+			err = MakeErrorWithFormat(ErrInvalidInput, "instant options in %s: %s", serializer, err)
+		}
+	}
+
+	if err == nil {
+		out = value.Format(layout)
+	}
+
+	return out, err
+}
@@ -88,7 +88,61 @@ var _ = Describe("Instant in EDN", func() {
 
 			edn, err := elem.Serialize(EvaEdnMimeType)
 			Ω(err).Should(BeNil())
-			Ω(edn).Should(BeEquivalentTo("#inst 2017-12-28T22:20:30Z"))
+			Ω(edn).Should(BeEquivalentTo("#inst \"2017-12-28T22:20:30.00000045Z\""))
+		})
+
+		It("should serialize the instant with the requested precision", func() {
+			elem := NewInstantElement(time.Date(2017, 12, 28, 22, 20, 30, 123456789, time.UTC))
+
+			for precision, expected := range map[string]string{
+				"0": "#inst \"2017-12-28T22:20:30Z\"",
+				"3": "#inst \"2017-12-28T22:20:30.123Z\"",
+				"6": "#inst \"2017-12-28T22:20:30.123456Z\"",
+				"9": "#inst \"2017-12-28T22:20:30.123456789Z\"",
+			} {
+				edn, err := elem.Serialize(EvaEdnMimeType + ";precision=" + SerializerMimeType(precision))
+				Ω(err).Should(BeNil())
+				Ω(edn).Should(BeEquivalentTo(expected), "precision "+precision)
+			}
+
+			edn, err := elem.Serialize(JSONMimeType + ";precision=3")
+			Ω(err).Should(BeNil())
+			Ω(edn).Should(BeEquivalentTo(`{"#inst":"2017-12-28T22:20:30.123Z"}`))
+		})
+
+		It("should not serialize the instant with an invalid precision", func() {
+			elem := NewInstantElement(testValue)
+
+			for _, precision := range []string{"-1", "10", "ms"} {
+				_, err := elem.Serialize(EvaEdnMimeType + ";precision=" + SerializerMimeType(precision))
+				Ω(err).ShouldNot(BeNil())
+				Ω(err).Should(test.HaveMessage(ErrInvalidInput))
+			}
+		})
+
+		It("should keep the precision and offset of the instant on a round trip", func() {
+			for _, value := range []string{
+				"#inst \"1985-04-12T23:20:50.52Z\"",
+				"#inst \"2017-12-28T22:20:30.001Z\"",
+				"#inst \"2017-12-28T22:20:30.123456789-07:00\"",
+				"#inst \"2017-12-28T22:20:30+05:30\"",
+			} {
+				elem, err := Parse(value)
+				Ω(err).Should(BeNil())
+
+				edn, err := elem.Serialize(EvaEdnMimeType)
+				Ω(err).Should(BeNil())
+				Ω(edn).Should(BeEquivalentTo(value))
+			}
+		})
+
+		It("should serialize the instants within a collection with the requested precision", func() {
+			elem, err := Parse("[#inst \"2017-12-28T22:20:30.123456Z\"]")
+			Ω(err).Should(BeNil())
+
+			edn, err := elem.Serialize(EvaEdnMimeType + ";precision=3")
+			Ω(err).Should(BeNil())
+			Ω(edn).Should(BeEquivalentTo("[#inst \"2017-12-28T22:20:30.123Z\"]"))
 		})
 
 		It("should serialize the instant without an issue", func() {
@@ -105,7 +159,7 @@ var _ = Describe("Instant in EDN", func() {
 		runParserTests(InstantType,
 			&testDefinition{"#inst \"1985-04-12T23:20:50.52Z\"", func() (string, interface{}, error) {
 				tag := "inst"
-				v, e := time.Parse(time.RFC3339, "1985-04-12T23:20:50.52Z")
+				v, e := time.Parse(time.RFC3339Nano, "1985-04-12T23:20:50.52Z")
 				return tag, v, e
 			}},
 		)
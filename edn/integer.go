@@ -19,6 +19,32 @@ import (
 	"strings"
 )
 
+// integerPattern is the lexer pattern for integers.
+const integerPattern = "[-+]?(0|[1-9][0-9]*)N?"
+
+// integerPrefix returns the length of the signed integer at the start of the token, 0 if there is none.
+func integerPrefix(token string) (i int) {
+	if len(token) > 0 && (token[0] == '-' || token[0] == '+') {
+		i++
+	}
+
+	switch {
+	case i < len(token) && token[i] == '0':
+		return i + 1
+	case i < len(token) && isDigit(token[i]):
+		for i++; i < len(token) && isDigit(token[i]); i++ {
+		}
+		return i
+	}
+	return 0
+}
+
+// integerTokenMatcher is a hand-written equivalent of integerPattern.
+func integerTokenMatcher(token string) bool {
+	n := integerPrefix(token)
+	return n > 0 && (n == len(token) || n == len(token)-1 && token[n:] == BigIntSuffix)
+}
+
 // init will add the element factory to the collection of factories
 func initInteger(lexer Lexer) (err error) {
 	if err = addElementTypeFactory(IntegerType, func(input interface{}) (elem Element, e error) {
@@ -29,15 +55,19 @@ func initInteger(lexer Lexer) (err error) {
 		}
 		return elem, e
 	}); err == nil {
-		lexer.AddPattern(IntegerPrimitive, "[-+]?(0|[1-9][0-9]*)N?", func(tag string, tokenValue string) (el Element, e error) {
-
-			if strings.HasSuffix(tokenValue, "N") {
-				tokenValue = strings.TrimSuffix(tokenValue, "N")
-			}
+		lexer.AddPattern(IntegerPrimitive, integerPattern, func(tag string, tokenValue string) (el Element, e error) {
 
+			// N suffixed literals are always big integers, other literals are promoted when they overflow a long.
 			var v int64
-			if v, e = strconv.ParseInt(tokenValue, 10, 64); e == nil {
+			if strings.HasSuffix(tokenValue, BigIntSuffix) {
+				el, e = parseBigInt(tokenValue)
+			} else if v, e = strconv.ParseInt(tokenValue, 10, 64); e == nil {
 				el = NewIntegerElement(v)
+			} else if numErr, is := e.(*strconv.NumError); is && numErr.Err == strconv.ErrRange {
+				el, e = parseBigInt(tokenValue)
+			}
+
+			if e == nil {
 				e = el.SetTag(tag)
 			}
 
@@ -59,6 +89,10 @@ func NewIntegerElement(value int64) (elem Element) {
 				out = TagPrefix + tag + " "
 			}
 			out += strconv.FormatInt(value.(int64), 10)
+		case JSONMimeType:
+			out = jsonTagged(tag, strconv.FormatInt(value.(int64), 10))
+		case TransitMimeType:
+			out, e = transitString(tag, value)
 		default:
 			e = MakeError(ErrUnknownMimeType, serializer.MimeType())
 		}
@@ -107,12 +107,8 @@ var _ = Describe("Integer in EDN", func() {
 			&testDefinition{"1", 1},
 			&testDefinition{"-1", -1},
 			&testDefinition{"1234", 1234},
-			&testDefinition{"0N", 0},
-			&testDefinition{"+0N", 0},
-			&testDefinition{"-0N", 0},
-			&testDefinition{"1N", 1},
-			&testDefinition{"-1N", -1},
-			&testDefinition{"1234N", 1234},
+			&testDefinition{"9223372036854775807", int64(9223372036854775807)},
+			&testDefinition{"-9223372036854775808", int64(-9223372036854775808)},
 		)
 	})
 })
@@ -0,0 +1,328 @@
+// Copyright 2018-2019 Workiva Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package edn
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"strings"
+)
+
+// JSON mapping
+//
+// The JSON serializer (JSONMimeType) maps the elements as follows:
+//
+//   nil                     -> null
+//   boolean                 -> true or false
+//   integer and decimals    -> number, doubles and floats always have a fraction or exponent, e.g. 1.0
+//   string                  -> string
+//   character               -> string holding the single character
+//   keyword                 -> string with the keyword prefix, e.g. ":book/title"
+//   symbol                  -> {"#symbol": "my/symbol"}
+//   list and vector         -> array
+//   set                     -> {"#set": [...]}
+//   map                     -> object, keys are strings. String keys are used as is, keywords keep their prefix and
+//                              any other key is the EDN serialization of the key.
+//   tagged element          -> {"#tag": <element>}, e.g. #my/tag 1 -> {"#my/tag": 1}
+//   instant                 -> {"#inst": "1985-04-12T23:20:50Z"}
+//   uuid                    -> {"#uuid": "f81d4fae-7dec-11d0-a765-00a0c91e6bf6"}
+//   uri                     -> {"#uri": "http://example.com/a"}
+//   bytes                   -> {"#bytes": "AQID"}, the bytes are base64 encoded
+//
+// When parsing JSON the mapping is reversed. Since JSON has no list or character type, arrays become vectors and
+// characters become strings. Numbers with a fraction or exponent become doubles. Objects become maps that keep the
+// order of their members.
+//
+// Any string, value or key, that starts with the keyword prefix and is a valid keyword is read as a keyword. This
+// means a string element such as ":x" does not round trip through JSON, it is read back as the keyword :x.
+
+const (
+
+	// jsonTagSymbol is the tag used in JSON for symbols.
+	jsonTagSymbol = "symbol"
+
+	// jsonTagSet is the tag used in JSON for sets.
+	jsonTagSet = "set"
+)
+
+// jsonQuote quotes the string for JSON.
+func jsonQuote(value string) string {
+	var buffer bytes.Buffer
+	encoder := json.NewEncoder(&buffer)
+	encoder.SetEscapeHTML(false)
+
+	// encoding a string can not fail.
+	_ = encoder.Encode(value)
+	return strings.TrimSuffix(buffer.String(), "\n")
+}
+
+// jsonTagged wraps the JSON value in the tag object if the tag exists.
+func jsonTagged(tag string, value string) string {
+	if len(tag) > 0 {
+		value = "{" + jsonQuote(TagPrefix+tag) + ":" + value + "}"
+	}
+	return value
+}
+
+// jsonKey returns the JSON object key for the map key.
+func jsonKey(key Element) (str string, err error) {
+	switch key.ElementType() {
+	case StringType:
+		str = key.Value().(string)
+	default:
+		str, err = key.Serialize(EvaEdnMimeType)
+	}
+	return jsonQuote(str), err
+}
+
+// writeJSONCollection streams the collection onto the writer as JSON.
+func writeJSONCollection(writer io.Writer, serializer Serializer, tag string, val *collectionElemImpl, hasKey bool) (err error) {
+
+	start, end := "[", "]"
+	switch {
+	case hasKey:
+		start, end = "{", "}"
+	case val.ElementType() == SetType:
+		start, end = "{"+jsonQuote(TagPrefix+jsonTagSet)+":[", "]}"
+	}
+
+	if len(tag) > 0 {
+		start = "{" + jsonQuote(TagPrefix+tag) + ":" + start
+		end += "}"
+	}
+
+	_, err = io.WriteString(writer, start)
+
+	var entries [][2]Element
+	if err == nil {
+		entries, err = val.entries(serializer)
+	}
+
+	for i := 0; i < len(entries) && err == nil; i++ {
+		key, child := entries[i][0], entries[i][1]
+		if i > 0 {
+			_, err = io.WriteString(writer, ",")
+		}
+
+		if err == nil && hasKey {
+			var str string
+			if str, err = jsonKey(key); err == nil {
+				_, err = io.WriteString(writer, str+":")
+			}
+		}
+
+		if err == nil && child != nil {
+			err = child.SerializeTo(writer, serializer)
+		}
+	}
+
+	if err == nil {
+		_, err = io.WriteString(writer, end)
+	}
+
+	return err
+}
+
+// MarshalJSON serializes the element into JSON.
+func (elem *baseElemImpl) MarshalJSON() (data []byte, err error) {
+	var str string
+	if str, err = elem.Serialize(JSONMimeType); err == nil {
+		data = []byte(str)
+	}
+	return data, err
+}
+
+// ParseJSON parses the JSON data into an element. See the JSON mapping for how the values are converted.
+func ParseJSON(data string) (elem Element, err error) {
+
+	decoder := json.NewDecoder(strings.NewReader(data))
+	decoder.UseNumber()
+
+	var value interface{}
+	if value, err = readJSON(decoder); err == nil {
+		if _, err = decoder.Token(); err == io.EOF {
+			elem, err = fromJSON(value)
+		} else {
+			err = MakeError(ErrParserError, "Expected one JSON value")
+		}
+	} else {
+		err = MakeError(ErrParserError, err)
+	}
+
+	return elem, err
+}
+
+// jsonMember is a key and value of a JSON object.
+type jsonMember struct {
+	key   string
+	value interface{}
+}
+
+// jsonObject holds the members of a JSON object in the order they were read.
+type jsonObject []jsonMember
+
+// readJSON reads the next value from the decoder. Objects are read as a jsonObject so that the order of the members
+// is kept, the other values are read as they are by encoding/json.
+func readJSON(decoder *json.Decoder) (value interface{}, err error) {
+
+	var token json.Token
+	if token, err = decoder.Token(); err != nil {
+		return nil, err
+	}
+
+	switch token {
+	case json.Delim('['):
+		items := []interface{}{}
+		for err == nil && decoder.More() {
+			var item interface{}
+			if item, err = readJSON(decoder); err == nil {
+				items = append(items, item)
+			}
+		}
+		value = items
+	case json.Delim('{'):
+		object := jsonObject{}
+		for err == nil && decoder.More() {
+			var key json.Token
+			if key, err = decoder.Token(); err == nil {
+				var item interface{}
+				if item, err = readJSON(decoder); err == nil {
+					object = append(object, jsonMember{key: key.(string), value: item})
+				}
+			}
+		}
+		value = object
+	default:
+		return token, nil
+	}
+
+	// read the closing delimiter.
+	if err == nil {
+		_, err = decoder.Token()
+	}
+
+	return value, err
+}
+
+// fromJSONString converts the JSON string into a keyword if it is one, otherwise a string element.
+func fromJSONString(value string) (elem Element) {
+	if strings.HasPrefix(value, KeywordPrefix) {
+		if keyword, err := NewKeywordElement(value); err == nil {
+			elem = keyword
+		}
+	}
+
+	if elem == nil {
+		elem = NewStringElement(value)
+	}
+
+	return elem
+}
+
+// fromJSONTagged converts the single entry JSON object into the tagged element.
+func fromJSONTagged(tag string, value interface{}) (elem Element, err error) {
+
+	switch str, isStr := value.(string); {
+	case tag == jsonTagSymbol && isStr:
+		elem, err = NewSymbolElement(str)
+	case tag == jsonTagSet:
+		if items, is := value.([]interface{}); is {
+			var children []Element
+			if children, err = fromJSONItems(items); err == nil {
+				elem, err = NewSet(children...)
+			}
+		} else {
+			err = MakeErrorWithFormat(ErrParserError, "Expected an array for the set: %T", value)
+		}
+	default:
+		if proc, has := stringProcessors[tag]; has && isStr {
+			if elem, err = proc(str); err == nil {
+				err = elem.SetTag(tag)
+			}
+		} else if elem, err = fromJSON(value); err == nil {
+			if err = elem.SetTag(tag); err == nil {
+				elem, err = readTagged(elem)
+			}
+		}
+	}
+
+	return elem, err
+}
+
+// fromJSONItems converts the JSON array items into elements.
+func fromJSONItems(items []interface{}) (children []Element, err error) {
+	children = make([]Element, 0, len(items))
+	for _, item := range items {
+		var child Element
+		if child, err = fromJSON(item); err != nil {
+			break
+		}
+		children = append(children, child)
+	}
+	return children, err
+}
+
+// fromJSON converts the decoded JSON value into an element.
+func fromJSON(value interface{}) (elem Element, err error) {
+
+	switch v := value.(type) {
+	case nil:
+		elem = NewNilElement()
+	case bool:
+		elem = NewBooleanElement(v)
+	case json.Number:
+		if i, e := v.Int64(); e == nil {
+			elem = NewIntegerElement(i)
+		} else if !strings.ContainsAny(v.String(), ".eE") {
+			elem, err = parseBigInt(v.String())
+		} else {
+			var f float64
+			if f, err = v.Float64(); err == nil {
+				elem = NewDoubleElement(f)
+			}
+		}
+	case string:
+		elem = fromJSONString(v)
+	case []interface{}:
+		var children []Element
+		if children, err = fromJSONItems(v); err == nil {
+			elem, err = NewVector(children...)
+		}
+	case jsonObject:
+		if len(v) == 1 && strings.HasPrefix(v[0].key, TagPrefix) {
+			elem, err = fromJSONTagged(strings.TrimPrefix(v[0].key, TagPrefix), v[0].value)
+		} else {
+			var coll CollectionElement
+			if coll, err = NewMap(); err == nil {
+				for _, member := range v {
+					var child Element
+					if child, err = fromJSON(member.value); err == nil {
+						err = coll.Append(fromJSONString(member.key), child)
+					}
+
+					if err != nil {
+						break
+					}
+				}
+				elem = coll
+			}
+		}
+	default:
+		err = MakeErrorWithFormat(ErrParserError, "Unsupported JSON value: %T", v)
+	}
+
+	return elem, err
+}
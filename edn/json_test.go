@@ -0,0 +1,209 @@
+// Copyright 2018-2019 Workiva Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package edn
+
+import (
+	"encoding/json"
+	"math"
+	"time"
+
+	"github.com/Workiva/eva-client-go/test"
+	"github.com/mattrobenolt/gocql/uuid"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("JSON serialization", func() {
+
+	It("should be a known serializer", func() {
+		serializer, err := GetSerializer("application/json; charset=utf-8")
+		Ω(err).Should(BeNil())
+		Ω(serializer.MimeType()).Should(BeEquivalentTo(JSONMimeType))
+	})
+
+	Context("primitives", func() {
+
+		keyword, _ := NewKeywordElement("book/title")
+		symbol, _ := NewSymbolElement("my/symbol")
+		id := uuid.TimeUUID()
+		inst := time.Date(1985, time.April, 12, 23, 20, 50, 0, time.UTC)
+
+		tests := map[string]Element{
+			"null":                             NewNilElement(),
+			"true":                             NewBooleanElement(true),
+			"-42":                              NewIntegerElement(-42),
+			"1.5":                              NewFloatElement(1.5),
+			"2.0":                              NewDoubleElement(2),
+			"1e+21":                            NewDoubleElement(1e21),
+			`"say \"hi\" <now>"`:               NewStringElement(`say "hi" <now>`),
+			`"a"`:                              NewCharacterElement('a'),
+			`":book/title"`:                    keyword,
+			`{"#symbol":"my/symbol"}`:          symbol,
+			`{"#inst":"1985-04-12T23:20:50Z"}`: NewInstantElement(inst),
+			`{"#uuid":"` + id.String() + `"}`:  NewUUIDElement(id),
+		}
+
+		for expected, elem := range tests {
+			expected, elem := expected, elem
+			It("should serialize "+expected, func() {
+				str, err := elem.Serialize(JSONMimeType)
+				Ω(err).Should(BeNil())
+				Ω(str).Should(BeEquivalentTo(expected))
+			})
+		}
+
+		It("should not serialize infinity", func() {
+			_, err := NewFloatElement(math.Inf(1)).Serialize(JSONMimeType)
+			Ω(err).Should(test.HaveMessage(ErrInvalidInput))
+		})
+
+		It("should wrap tagged elements", func() {
+			elem := NewIntegerElement(1)
+			Ω(elem.SetTag("my/tag")).Should(BeNil())
+
+			str, err := elem.Serialize(JSONMimeType)
+			Ω(err).Should(BeNil())
+			Ω(str).Should(BeEquivalentTo(`{"#my/tag":1}`))
+		})
+	})
+
+	Context("collections", func() {
+
+		It("should serialize lists and vectors as arrays", func() {
+			list, err := NewList(NewIntegerElement(1), NewStringElement("two"))
+			Ω(err).Should(BeNil())
+
+			var vector CollectionElement
+			vector, err = NewVector(list, NewNilElement())
+			Ω(err).Should(BeNil())
+
+			str, err := vector.Serialize(JSONMimeType)
+			Ω(err).Should(BeNil())
+			Ω(str).Should(BeEquivalentTo(`[[1,"two"],null]`))
+		})
+
+		It("should serialize sets", func() {
+			set, err := NewSet(NewIntegerElement(1))
+			Ω(err).Should(BeNil())
+			Ω(set.SetTag("my/tag")).Should(BeNil())
+
+			str, err := set.Serialize(JSONMimeType)
+			Ω(err).Should(BeNil())
+			Ω(str).Should(BeEquivalentTo(`{"#my/tag":{"#set":[1]}}`))
+		})
+
+		It("should serialize map keys as strings", func() {
+			elem, err := Parse(`[{:db/id 1} {"name" 2} {3 [4]}]`)
+			Ω(err).Should(BeNil())
+
+			str, err := elem.Serialize(JSONMimeType)
+			Ω(err).Should(BeNil())
+			Ω(str).Should(BeEquivalentTo(`[{":db/id":1},{"name":2},{"3":[4]}]`))
+		})
+
+		It("should implement json.Marshaler", func() {
+			elem, err := Parse(`{:items #{:a}}`)
+			Ω(err).Should(BeNil())
+
+			data, err := json.Marshal(struct {
+				Data Element `json:"data"`
+			}{elem})
+			Ω(err).Should(BeNil())
+			Ω(string(data)).Should(BeEquivalentTo(`{"data":{":items":{"#set":[":a"]}}}`))
+		})
+	})
+
+	Context("parsing", func() {
+
+		It("should parse the mapping back into elements", func() {
+			elem, err := ParseJSON(`{
+				":ex-info": {":code": 3000, "message": "bad"},
+				"values": [1, 2.5, true, null, {"#symbol": "my/symbol"}],
+				"set": {"#set": [":a", ":b"]},
+				"inst": {"#inst": "1985-04-12T23:20:50Z"},
+				"tagged": {"#my/tag": [1]},
+				"smile": ":)"
+			}`)
+			Ω(err).Should(BeNil())
+
+			expected, err := Parse(`{
+				:ex-info {:code 3000 "message" "bad"}
+				"values" [1 2.5E+00 true nil my/symbol]
+				"set" #{:a :b}
+				"inst" #inst "1985-04-12T23:20:50Z"
+				"tagged" #my/tag [1]
+				"smile" ":)"}`)
+			Ω(err).Should(BeNil())
+			Ω(elem.Equals(expected)).Should(BeTrue())
+		})
+
+		It("should keep the order of the object members", func() {
+			elem, err := ParseJSON(`{"z": 1, "a": {"y": 2, "b": 3, "x": 4}, "m": 5}`)
+			Ω(err).Should(BeNil())
+			for i := 0; i < 10; i++ {
+				str, err := elem.Serialize(JSONMimeType)
+				Ω(err).Should(BeNil())
+				Ω(str).Should(BeEquivalentTo(`{"z":1,"a":{"y":2,"b":3,"x":4},"m":5}`))
+			}
+		})
+
+		It("should read integral doubles back as doubles", func() {
+			str, err := NewDoubleElement(1).Serialize(JSONMimeType)
+			Ω(err).Should(BeNil())
+
+			elem, err := ParseJSON(str)
+			Ω(err).Should(BeNil())
+			Ω(elem.ElementType()).Should(BeEquivalentTo(DoubleType))
+		})
+
+		It("should read strings with the keyword prefix as keywords", func() {
+			str, err := NewStringElement(":x").Serialize(JSONMimeType)
+			Ω(err).Should(BeNil())
+
+			elem, err := ParseJSON(str)
+			Ω(err).Should(BeNil())
+			Ω(elem.ElementType()).Should(BeEquivalentTo(KeywordType))
+		})
+
+		It("should round trip through the serializer", func() {
+			elem, err := Parse(`[:a #{1 "b"} {:c my/symbol} #my/tag "d"]`)
+			Ω(err).Should(BeNil())
+
+			str, err := elem.Serialize(JSONMimeType)
+			Ω(err).Should(BeNil())
+
+			parsed, err := ParseJSON(str)
+			Ω(err).Should(BeNil())
+			Ω(parsed.Equals(elem)).Should(BeTrue())
+		})
+
+		It("should fail on bad input", func() {
+			_, err := ParseJSON(`{"a": `)
+			Ω(err).Should(test.HaveMessage(ErrParserError))
+
+			_, err = ParseJSON(`1 2`)
+			Ω(err).Should(test.HaveMessage(ErrParserError))
+
+			_, err = ParseJSON(`[1, 2`)
+			Ω(err).Should(test.HaveMessage(ErrParserError))
+
+			_, err = ParseJSON(``)
+			Ω(err).Should(test.HaveMessage(ErrParserError))
+
+			_, err = ParseJSON(`{"#set": 1}`)
+			Ω(err).Should(test.HaveMessage(ErrParserError))
+		})
+	})
+})
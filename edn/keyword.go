@@ -25,8 +25,18 @@ const (
 
 	// ErrInvalidKeyword defines the error for invalid keywords
 	ErrInvalidKeyword = ErrorMessage("Invalid keyword")
+
+	// keywordPattern is the lexer pattern for keywords.
+	keywordPattern = ":([*!?$%&=<>]|\\w)([-+*!?$%&=<>.#]|\\w)*(/([-+*!?$%&=<>.#]|\\w)*)?"
 )
 
+// keywordTokenMatcher is a hand-written equivalent of keywordPattern.
+func keywordTokenMatcher(token string) bool {
+	return len(token) > 1 && token[0] == KeywordPrefix[0] &&
+		(isWord(token[1]) || strings.IndexByte("*!?$%&=<>", token[1]) != -1) &&
+		namespacedTokenMatcher(token[2:], "-+*!?$%&=<>.#")
+}
+
 // init will add the element factory to the collection of factories
 func initKeyword(lexer Lexer) (err error) {
 	if err = addElementTypeFactory(KeywordType, func(input interface{}) (elem Element, e error) {
@@ -37,7 +47,7 @@ func initKeyword(lexer Lexer) (err error) {
 		}
 		return elem, e
 	}); err == nil {
-		lexer.AddPattern(SymbolPrimitive, ":([*!?$%&=<>]|\\w)([-+*!?$%&=<>.#]|\\w)*(/([-+*!?$%&=<>.#]|\\w)*)?", func(tag string, tokenValue string) (el Element, e error) {
+		lexer.AddPattern(SymbolPrimitive, keywordPattern, func(tag string, tokenValue string) (el Element, e error) {
 			tokenValue = strings.TrimSuffix(tokenValue, KeywordPrefix)
 			if el, e = NewKeywordElement(tokenValue); e == nil {
 				e = el.SetTag(tag)
@@ -16,11 +16,17 @@ package edn
 
 import (
 	"fmt"
-	"github.com/timtadh/lexmachine"
-	"github.com/timtadh/lexmachine/machines"
+	"regexp"
+	"sort"
 	"strings"
+	"sync"
+	"unicode/utf8"
 )
 
+// PrimitiveType orders the primitive patterns. Each token is only matched against the patterns of the priorities for
+// its shape: strings ("...") against StringPrimitive, characters (\c) against CharacterPrimitive, numbers (a digit,
+// optionally signed) against IntegerPrimitive then FloatPrimitive and everything else against LiteralPrimitive then
+// SymbolPrimitive. Patterns without any regular expression operators are looked up before the others.
 type PrimitiveType int
 
 const (
@@ -42,28 +48,10 @@ type collProcDef struct {
 	processor CollectionProcessor
 }
 
-type tokenType string
-
-const (
-
-	// because all blanks are skipped, all these token types are # of blanks.
-	skipToken    tokenType = " "
-	elementToken tokenType = "  "
-)
-
-func (tt tokenType) String() string {
-	switch tt {
-	case skipToken:
-		return "[Skip Token]"
-	case elementToken:
-		return "[Element]"
-	default:
-		return string(tt)
-	}
-}
-
-func (tt tokenType) Is(this string) bool {
-	return string(tt) == this
+// primitivePattern holds a compiled primitive pattern.
+type primitivePattern struct {
+	matcher   func(string) bool
+	processor PrimitiveProcessor
 }
 
 // Lexer defines the lexical analyser for the
@@ -77,68 +65,100 @@ type Lexer interface {
 	Parse(data string) (Element, error)
 }
 
-func splitTag(data []byte, possible string) (tag string, value string) {
+const (
 
-	// Special case, if the #{ appears then ignore the splitting and just return the value.
-	if full := string(data); !strings.HasPrefix(full, SetStartLiteral) && strings.HasPrefix(full, TagPrefix) {
-		parts := strings.Fields(full)
-		tag = parts[0]
-		value = strings.TrimPrefix(full, tag)
-		tag = strings.TrimPrefix(tag, TagPrefix)
-		value = strings.TrimSpace(value)
+	// the descriptions of the expected tokens used in the parse errors.
+	expectedElement = "element"
+	expectedTag     = "tag"
+	expectedEnd     = "end of input"
+)
 
-		if len(possible) > 0 && strings.HasSuffix(tag, possible) {
-			tag = strings.TrimSuffix(tag, possible)
-		}
-	} else {
-		value = full
-	}
+// tokenClasses maps the shape of a token to the priorities of the patterns that are tried.
+var tokenClasses = map[byte][]PrimitiveType{
+	'"':  {StringPrimitive},
+	'\\': {CharacterPrimitive},
+	'0':  {IntegerPrimitive, FloatPrimitive},
+	'a':  {LiteralPrimitive, SymbolPrimitive},
+}
 
-	return tag, value
+// tokenClass returns the class of the token, see tokenClasses.
+func tokenClass(token string) byte {
+	switch ch := token[0]; {
+	case ch == '"' || ch == '\\':
+		return ch
+	case isDigit(ch), (ch == '-' || ch == '+') && len(token) > 1 && isDigit(token[1]):
+		return '0'
+	default:
+		return 'a'
+	}
 }
 
-func buildTagPattern(pattern string, mustHasSpace bool) []byte {
+// isDigit checks if the byte is an ascii digit.
+func isDigit(ch byte) bool {
+	return ch >= '0' && ch <= '9'
+}
 
-	subPattern := "*"
-	if mustHasSpace {
-		subPattern = "+"
+// isHex checks if the string is made of hexadecimal digits.
+func isHex(value string) bool {
+	for i := 0; i < len(value); i++ {
+		if ch := value[i]; !isDigit(ch) && (ch < 'a' || ch > 'f') && (ch < 'A' || ch > 'F') {
+			return false
+		}
 	}
+	return true
+}
 
-	return []byte(fmt.Sprintf("(%s[A-Za-z][-A-Za-z0-9_/.]*(\\s)%s)?%s", TagPrefix, subPattern, pattern))
+// isAlpha checks if the byte is an ascii letter.
+func isAlpha(ch byte) bool {
+	return ch >= 'a' && ch <= 'z' || ch >= 'A' && ch <= 'Z'
 }
 
-func runScanner(scanner *lexmachine.Scanner) (tokType tokenType, elems []Element, err error) {
-	var t interface{}
+// isWord checks if the byte is a word character, the same as \w in a regular expression.
+func isWord(ch byte) bool {
+	return isAlpha(ch) || isDigit(ch) || ch == '_'
+}
 
-	var eos bool
-	for t, err, eos = scanner.Next(); !eos && err == nil; t, err, eos = scanner.Next() {
-		switch v := t.(type) {
-		case Element:
-			elems = append(elems, v)
-			tokType = elementToken
-		case tokenType:
-			tokType = v
-		}
+// isBlank checks if the byte is whitespace, commas are whitespace in edn.
+func isBlank(ch byte) bool {
+	switch ch {
+	case ' ', '\t', '\n', '\r', '\f', ',':
+		return true
+	}
+	return false
+}
 
-		if tokType != elementToken && tokType != skipToken {
-			break
-		}
+// endsToken checks if the byte ends a token.
+func endsToken(ch byte) bool {
+	switch ch {
+	case '(', ')', '[', ']', '{', '}', '"', ';':
+		return true
 	}
+	return isBlank(ch)
+}
 
-	if err != nil {
-		switch v := err.(type) {
-		case *machines.UnconsumedInput:
-			err = MakeError(ErrParserError, struct {
-				message string
-				elem    []Element
-			}{
-				v.Error(),
-				elems,
-			})
+// isTag checks if the token is a valid tag: a letter followed by letters, digits or any of -_/.
+func isTag(token string) bool {
+	for i := 0; i < len(token); i++ {
+		switch ch := token[i]; {
+		case isAlpha(ch):
+		case i > 0 && (isDigit(ch) || ch == '-' || ch == '_' || ch == '/' || ch == '.'):
+		default:
+			return false
 		}
 	}
+	return len(token) > 0
+}
 
-	return tokType, elems, err
+// tokenMatchers holds hand-written equivalents of the builtin patterns, they are used in place of the regular
+// expressions as they are much faster.
+var tokenMatchers = map[string]func(string) bool{
+	integerPattern:          integerTokenMatcher,
+	floatPattern:            floatTokenMatcher,
+	characterPattern:        characterTokenMatcher,
+	unicodeCharacterPattern: unicodeCharacterTokenMatcher,
+	symbolPattern:           symbolTokenMatcher,
+	keywordPattern:          keywordTokenMatcher,
+	stringPattern:           stringTokenMatcher,
 }
 
 ///// ----------------------------------------------
@@ -146,8 +166,15 @@ func runScanner(scanner *lexmachine.Scanner) (tokType tokenType, elems []Element
 type lexerImpl struct {
 	primitivePatterns  map[PrimitiveType]map[string]PrimitiveProcessor
 	collectionPatterns map[string]*collProcDef
-	lex                *lexmachine.Lexer
-	built              bool
+
+	// the compiled patterns, built on the first parse.
+	lock       sync.Mutex
+	built      bool
+	literals   map[string]PrimitiveProcessor
+	patterns   [lastPrimitivePriority][]*primitivePattern
+	starts     []*collProcDef
+	startBytes [256]bool
+	endBytes   [256]bool
 }
 
 // newLexer will create a new lexer.
@@ -155,100 +182,74 @@ func newLexer() (lexer Lexer, err error) {
 	lexer = &lexerImpl{
 		primitivePatterns:  map[PrimitiveType]map[string]PrimitiveProcessor{},
 		collectionPatterns: map[string]*collProcDef{},
-		lex:                lexmachine.NewLexer(),
-		built:              false,
 	}
 
 	return lexer, err
 }
 
-// completeStartup of the lexer
+// completeStartup of the lexer compiles the patterns.
 func (lexer *lexerImpl) completeStartup() (err error) {
 
-	if !lexer.built {
-
-		for i := PrimitiveType(0); i < lastPrimitivePriority; i++ {
-			if v, has := lexer.primitivePatterns[i]; has {
+	lexer.lock.Lock()
+	defer lexer.lock.Unlock()
 
-				for pattern, p := range v {
-					processor := p // this is required as the processor needs to have a local reference... yay golang oddities! :(
-					lexer.addPattern(buildTagPattern(pattern, true), func(scan *lexmachine.Scanner, match *machines.Match) (interface{}, error) {
-						tag, value := splitTag(match.Bytes, "")
-						return processor(tag, value)
-					})
-				}
-			}
-		}
-
-		endPatterns := map[string]bool{}
+	if !lexer.built {
 
-		lexSpecialChars := []string{
-			"\\", "[", "]", "{", "}", "(", ")",
-		}
+		literals := map[string]PrimitiveProcessor{}
+		var patterns [lastPrimitivePriority][]*primitivePattern
 
-		for _, def := range lexer.collectionPatterns {
-			processor := def.processor // and again boo - golang oddities! :(
-			end := def.end
-			start := def.start
+		for priority := lastPrimitivePriority - 1; priority >= 0 && err == nil; priority-- {
 
-			for _, c := range lexSpecialChars {
-				start = strings.Replace(start, c, "\\"+c, -1)
-				end = strings.Replace(end, c, "\\"+c, -1)
+			// sort the patterns so that the order they are tried in is stable.
+			var keys []string
+			for pattern := range lexer.primitivePatterns[priority] {
+				keys = append(keys, pattern)
 			}
+			sort.Strings(keys)
 
-			startRaw := def.start
-
-			if _, has := endPatterns[end]; !has {
-				lexer.addPattern([]byte(end), func(scan *lexmachine.Scanner, match *machines.Match) (interface{}, error) {
-					return tokenType(end), nil
-				})
-				endPatterns[end] = true
-			}
-
-			// Add the non tagged items.
-			lexer.addPattern(buildTagPattern(start, false), func(scan *lexmachine.Scanner, match *machines.Match) (v interface{}, e error) {
-				tag, _ := splitTag(match.Bytes, startRaw)
-
-				var tt tokenType
-				var children []Element
-				var c []Element
-
-				for tt, c, e = runScanner(scan); ; tt, c, e = runScanner(scan) {
-					stop := true
-					if e == nil {
-						children = append(children, c...)
-						switch {
-						case tt == elementToken:
-							stop = false
-						case tt.Is(end):
-						default:
-							e = MakeErrorWithFormat(ErrParserError, "Unexpected end token: '%s' instead of '%s'", tt.String(), end)
-						}
-					}
+			for _, pattern := range keys {
+				var matcher *regexp.Regexp
+				if matcher, err = regexp.Compile("^(?:" + pattern + ")$"); err != nil {
+					err = MakeErrorWithFormat(ErrParserError, "Invalid pattern: %s", err)
+					break
+				}
 
-					if stop {
-						break
+				processor := lexer.primitivePatterns[priority][pattern]
+				if literal, complete := matcher.LiteralPrefix(); complete {
+					literals[literal] = processor // lower priorities are added last and win.
+				} else {
+					tokenMatcher, has := tokenMatchers[pattern]
+					if !has {
+						tokenMatcher = matcher.MatchString
 					}
-				}
 
-				if e == nil {
-					v, e = processor(tag, children)
+					patterns[priority] = append(patterns[priority], &primitivePattern{
+						matcher:   tokenMatcher,
+						processor: processor,
+					})
 				}
-
-				return v, e
-			})
+			}
 		}
 
-		lexer.addPattern([]byte("(\\s|,)+"), func(scan *lexmachine.Scanner, match *machines.Match) (interface{}, error) {
-			return skipToken, nil
-		})
+		if err == nil {
+			var starts []*collProcDef
+			for _, def := range lexer.collectionPatterns {
+				starts = append(starts, def)
+				lexer.startBytes[def.start[0]] = true
+				lexer.endBytes[def.end[0]] = true
+			}
 
-		lexer.addPattern([]byte(";[^\\n]*(\\n)?"), func(scan *lexmachine.Scanner, match *machines.Match) (interface{}, error) {
-			return skipToken, nil
-		})
+			// the longest start wins, e.g. #{ over {.
+			sort.Slice(starts, func(i, j int) bool {
+				if len(starts[i].start) != len(starts[j].start) {
+					return len(starts[i].start) > len(starts[j].start)
+				}
+				return starts[i].start < starts[j].start
+			})
 
-		compile := lexer.lex.CompileNFA
-		if err = compile(); err == nil {
+			lexer.literals = literals
+			lexer.patterns = patterns
+			lexer.starts = starts
 			lexer.built = true
 		}
 	}
@@ -259,18 +260,28 @@ func (lexer *lexerImpl) completeStartup() (err error) {
 // Parse the value
 func (lexer *lexerImpl) Parse(data string) (elem Element, err error) {
 	if err = lexer.completeStartup(); err == nil {
-		var scanner *lexmachine.Scanner
-		if scanner, err = lexer.lex.Scanner([]byte(data)); err == nil {
-			var elems []Element
-			if _, elems, err = runScanner(scanner); err == nil {
-				switch {
-				case len(elems) == 1:
-					elem = elems[0]
-				default:
-					err = MakeErrorWithFormat(ErrParserError, "Expected one result, got: %d", len(elems))
+		p := &parser{
+			lexer: lexer,
+			data:  data,
+		}
+
+		var end string
+		if elem, end, err = p.readElement(); err == nil {
+			switch {
+			case len(end) > 0:
+				err = p.fail(p.pos-len(end), expectedElement, "Unexpected end token: '%s'", end)
+			case elem == nil:
+				err = p.fail(p.pos, expectedElement, "Expected one result, got: 0")
+			default:
+				if err = p.skipIgnored(); err == nil && p.pos < len(p.data) {
+					err = p.fail(p.pos, expectedEnd, "Expected one result, got more")
 				}
 			}
 		}
+
+		if err != nil {
+			elem = nil
+		}
 	}
 
 	return elem, err
@@ -301,55 +312,253 @@ func (lexer *lexerImpl) AddCollectionPattern(start string, end string, processor
 	}
 }
 
-func (lexer *lexerImpl) addPattern(pattern []byte, action lexmachine.Action) {
+///// ----------------------------------------------
 
-	lexer.lex.Add(pattern, action)
+// parser is a recursive descent parser over the data, it holds the state of a single call to Parse.
+type parser struct {
+	lexer *lexerImpl
+	data  string
+	pos   int
 
-	// NOTE:
-	//   The following code here is to diagnose pattern issues.
+	// stack holds the children of the collections being read, it is shared to save on allocations.
+	stack []Element
+}
 
-	/*
+// fail creates the parse error for the syntax error at the offset.
+func (p *parser) fail(offset int, expected string, format string, details ...interface{}) error {
+	return newParseError(ErrParserError, fmt.Sprintf(format, details...), p.data, offset, expected, nil)
+}
 
-		fmt.Println("Pattern: ", string(pattern))
-		lex := lexmachine.NewLexer()
+// wrap creates the parse error for the error returned by a processor for the element at the offset. Errors created
+// by this package keep their message.
+func (p *parser) wrap(offset int, err error) error {
+	switch v := err.(type) {
+	case *Error:
+		return newParseError(v.message, v.details, p.data, offset, "", err)
+	case *ParseError:
+		return err
+	default:
+		return newParseError(ErrParserError, err.Error(), p.data, offset, "", err)
+	}
+}
 
-		lex.Add([]byte(pattern), func(scan *lexmachine.Scanner, match *machines.Match) (interface{}, error) {
-			fmt.Println("  Matches empty: ", string(pattern))
-			return true, nil
-		})
+// skipBlanks moves past whitespace and comments.
+func (p *parser) skipBlanks() {
+	for p.pos < len(p.data) {
+		switch ch := p.data[p.pos]; {
+		case isBlank(ch):
+			p.pos++
+		case ch == ';':
+			if end := strings.IndexByte(p.data[p.pos:], '\n'); end != -1 {
+				p.pos += end + 1
+			} else {
+				p.pos = len(p.data)
+			}
+		default:
+			return
+		}
+	}
+}
 
-		compile := lex.CompileNFA
+// skipIgnored moves past blanks, comments and discarded elements. The discarded elements must still be valid.
+func (p *parser) skipIgnored() (err error) {
+	for p.skipBlanks(); err == nil && strings.HasPrefix(p.data[p.pos:], DiscardPrefix); p.skipBlanks() {
+		start := p.pos
+		p.pos += len(DiscardPrefix)
 
-		var err error
-		if err = compile(); err == nil {
+		var discarded Element
+		if discarded, _, err = p.readElement(); err == nil && discarded == nil {
+			err = p.fail(start+len(DiscardPrefix), expectedElement, "Missing element to discard")
+		}
+	}
+	return err
+}
 
-			var s *lexmachine.Scanner
-			if s, err = lex.Scanner([]byte("")); err == nil {
+// readElement reads the next element. At the end of the data the element is nil, when the next token closes a
+// collection the element is nil and the end token is returned.
+func (p *parser) readElement() (elem Element, end string, err error) {
 
-				var tok interface{}
-				var end bool
+	if err = p.skipIgnored(); err != nil || p.pos >= len(p.data) {
+		return nil, "", err
+	}
 
-				if tok, err, end = s.Next(); err == nil {
-					if !end {
-						err = errors.New("expected an end of string")
-					} else {
-						if tok != nil {
-							switch v := tok.(type) {
-							case bool:
-								if v {
-									err = errors.New("expected false")
-								}
-							default:
-								err = errors.New(fmt.Sprintf("expected a bool: %#v", tok))
-							}
-						}
-					}
-				}
+	var tag string
+	if def := p.collectionStart(); def != nil {
+		elem, err = p.readCollection(tag, def)
+	} else if ch := p.data[p.pos]; p.lexer.endBytes[ch] {
+		end = p.data[p.pos : p.pos+1]
+		p.pos++
+	} else {
+		if ch == TagPrefix[0] && !strings.HasPrefix(p.data[p.pos:], SymbolicPrefix) {
+			if tag, err = p.readTag(); err != nil {
+				return nil, "", err
+			}
+		}
+
+		if def := p.collectionStart(); def != nil {
+			elem, err = p.readCollection(tag, def)
+		} else {
+			elem, err = p.readPrimitive(tag)
+		}
+	}
+
+	return elem, end, err
+}
+
+// collectionStart returns the collection that starts at the current position, if any.
+func (p *parser) collectionStart() *collProcDef {
+	if p.pos < len(p.data) && p.lexer.startBytes[p.data[p.pos]] {
+		for _, def := range p.lexer.starts {
+			if strings.HasPrefix(p.data[p.pos:], def.start) {
+				return def
+			}
+		}
+	}
+	return nil
+}
+
+// readTag reads the tag and the blanks after it.
+func (p *parser) readTag() (tag string, err error) {
+
+	p.pos += len(TagPrefix)
+	tag = p.readToken()
+	if !isTag(tag) {
+		return "", p.fail(p.pos-len(tag), expectedTag, "Invalid tag: '%s%s'", TagPrefix, tag)
+	}
+
+	if err = p.skipIgnored(); err != nil {
+		return "", err
+	}
+
+	switch {
+	case p.pos >= len(p.data):
+		err = p.fail(p.pos, expectedElement, "Missing element for the tag: '%s%s'", TagPrefix, tag)
+	case p.data[p.pos] == TagPrefix[0] && p.collectionStart() == nil && !strings.HasPrefix(p.data[p.pos:], SymbolicPrefix):
+		err = p.fail(p.pos, expectedElement, "Nested tags are not supported: '%s%s'", TagPrefix, tag)
+	}
+
+	return tag, err
+}
+
+// readToken reads up to the next delimiter.
+func (p *parser) readToken() string {
+	start := p.pos
+	for p.pos < len(p.data) && !endsToken(p.data[p.pos]) {
+		p.pos++
+	}
+	return p.data[start:p.pos]
+}
+
+// readPrimitive reads the token and runs the processor of the first pattern that matches.
+func (p *parser) readPrimitive(tag string) (elem Element, err error) {
+
+	start := p.pos
+	switch p.data[p.pos] {
+	case '"':
+		err = p.skipString()
+	case '\\':
+		// the character after the prefix is always part of the token.
+		_, size := utf8.DecodeRuneInString(p.data[p.pos+1:])
+		p.pos += 1 + size
+		p.readToken()
+	default:
+		if p.readToken(); start == p.pos {
+			p.pos++
+			err = p.fail(start, expectedElement, "Unexpected character: '%c'", p.data[start])
+		}
+	}
+
+	token := p.data[start:p.pos]
+	if err == nil {
+		processor, has := p.lexer.literals[token]
+		if !has {
+			processor, has = p.lexer.match(token)
+		}
+
+		if !has {
+			err = p.fail(start, expectedElement, "Unrecognized token: '%s'", token)
+		} else if elem, err = processor(tag, token); err == nil {
+			elem, err = readTagged(elem)
+		}
+
+		if err != nil {
+			err = p.wrap(start, err)
+		}
+	}
+
+	return elem, err
+}
+
+// skipString moves past the string at the current position.
+func (p *parser) skipString() (err error) {
+	start := p.pos
+	for p.pos++; p.pos < len(p.data); p.pos++ {
+		switch p.data[p.pos] {
+		case '\\':
+			p.pos++
+		case '"':
+			p.pos++
+			return nil
+		}
+	}
+	return p.fail(start, `'"'`, "Unterminated string")
+}
+
+// match finds the processor of the first pattern that matches the whole token.
+func (lexer *lexerImpl) match(token string) (PrimitiveProcessor, bool) {
+	for _, priority := range tokenClasses[tokenClass(token)] {
+		for _, pattern := range lexer.patterns[priority] {
+			if pattern.matcher(token) {
+				return pattern.processor, true
 			}
 		}
+	}
+	return nil, false
+}
+
+// readCollection reads the children up to the end token and runs the processor of the collection.
+func (p *parser) readCollection(tag string, def *collProcDef) (elem Element, err error) {
+
+	start := p.pos
+	p.pos += len(def.start)
+	base := len(p.stack)
+
+	for {
+		var child Element
+		var end string
+		if child, end, err = p.readElement(); err != nil {
+			break
+		}
+
+		if len(end) > 0 {
+			if end != def.end {
+				err = p.fail(p.pos-len(end), "'"+def.end+"'", "Unexpected end token: '%s' instead of '%s'", end, def.end)
+			}
+			break
+		}
+
+		if child == nil {
+			err = p.fail(p.pos, "'"+def.end+"'", "Missing end token: '%s' for the collection starting at offset %d", def.end, start)
+			break
+		}
+
+		p.stack = append(p.stack, child)
+	}
+
+	if err == nil {
+		// the processor gets its own copy as the stack is reused.
+		children := make([]Element, len(p.stack)-base)
+		copy(children, p.stack[base:])
+
+		if elem, err = def.processor(tag, children); err == nil {
+			elem, err = readTagged(elem)
+		}
 
 		if err != nil {
-			fmt.Println("  Error: ", err)
+			err = p.wrap(start, err)
 		}
-	*/
+	}
+
+	p.stack = p.stack[:base]
+	return elem, err
 }
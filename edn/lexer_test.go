@@ -15,6 +15,11 @@
 package edn
 
 import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"testing"
+
 	"github.com/Workiva/eva-client-go/test"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -65,15 +70,104 @@ var _ = Describe("Lexer tests", func() {
 		Ω(err).Should(test.HaveMessage(ErrParserError))
 	})
 
-	It("should break the creation if there is an error", func() {
-		tt := tokenType("  ")
-		Ω(tt.String()).Should(BeEquivalentTo("[Element]"))
+	It("should classify the tokens", func() {
+		Ω(tokenClass(`"foo"`)).Should(BeEquivalentTo('"'))
+		Ω(tokenClass(`\a`)).Should(BeEquivalentTo('\\'))
+		Ω(tokenClass("-12")).Should(BeEquivalentTo('0'))
+		Ω(tokenClass("1.5")).Should(BeEquivalentTo('0'))
+		Ω(tokenClass("-")).Should(BeEquivalentTo('a'))
+		Ω(tokenClass("+foo")).Should(BeEquivalentTo('a'))
+		Ω(tokenClass(":foo")).Should(BeEquivalentTo('a'))
+	})
+
+	It("should validate the tags", func() {
+		Ω(isTag("my/taco")).Should(BeTrue())
+		Ω(isTag("a-b_c.d9")).Should(BeTrue())
+		Ω(isTag("")).Should(BeFalse())
+		Ω(isTag("9a")).Should(BeFalse())
+		Ω(isTag("a:b")).Should(BeFalse())
+	})
+
+	It("should match the same tokens as the builtin patterns", func() {
+		alphabet := []string{"0", "1", "9", "a", "f", "u", "N", "M", "e", "E", "_", "-", "+", ".", "/", ":", "#", "*",
+			"<", "\\", "\"", "'", " ", "(", "~", "@"}
+
+		tokens := []string{"", "-0.5e+10M", "12345N", `"a\u00e9b"`, `"\u00e"`, `\u00e9`, `\u00eg`, ":db/id", "my.ns/name"}
+		var build func(prefix string, length int)
+		build = func(prefix string, length int) {
+			tokens = append(tokens, prefix)
+			if length > 0 {
+				for _, ch := range alphabet {
+					build(prefix+ch, length-1)
+				}
+			}
+		}
+		build("", 3)
+
+		for pattern, matcher := range tokenMatchers {
+			expected := regexp.MustCompile("^(?:" + pattern + ")$")
+			for _, token := range tokens {
+				Ω(matcher(token)).Should(BeEquivalentTo(expected.MatchString(token)), pattern+" "+token)
+			}
+		}
+
+		expected := regexp.MustCompile(symbolRegex)
+		for _, token := range tokens {
+			Ω(symbolMatcher(token)).Should(BeEquivalentTo(expected.MatchString(token)), token)
+		}
+	})
+
+	It("should fail on bad patterns", func() {
+		lexer, err := newLexer()
+		Ω(err).Should(BeNil())
+
+		lexer.AddPattern(SymbolPrimitive, "[a-", nil)
+		_, err = lexer.Parse("foo")
+		Ω(err).Should(test.HaveMessage(ErrParserError))
 	})
 
-	It("should break the creation if there is an error", func() {
-		tag, value := splitTag([]byte("#my/taco foobar"), "taco")
-		Ω(tag).Should(BeEquivalentTo("my/"))
-		Ω(value).Should(BeEquivalentTo("foobar"))
+	It("should use the patterns of the token class", func() {
+		lexer, err := newLexer()
+		Ω(err).Should(BeNil())
+
+		lexer.AddPattern(IntegerPrimitive, "[0-9]+", func(tag string, tokenValue string) (Element, error) {
+			return NewStringElement("integer"), nil
+		})
+		lexer.AddPattern(SymbolPrimitive, "[a-z0-9]+", func(tag string, tokenValue string) (Element, error) {
+			return NewStringElement("symbol"), nil
+		})
+		lexer.AddPattern(LiteralPrimitive, "yes", func(tag string, tokenValue string) (Element, error) {
+			return NewStringElement("literal"), nil
+		})
+
+		for data, expected := range map[string]string{"12": "integer", "a12": "symbol", "yes": "literal", "yess": "symbol"} {
+			elem, err := lexer.Parse(data)
+			Ω(err).Should(BeNil(), data)
+			Ω(elem.Value()).Should(BeEquivalentTo(expected), data)
+		}
+
+		_, err = lexer.Parse("A")
+		Ω(err).Should(test.HaveMessage(ErrParserError))
+	})
+
+	It("should pass a copy of the children to the collection processors", func() {
+		lexer, err := newLexer()
+		Ω(err).Should(BeNil())
+
+		var kept [][]Element
+		lexer.AddPattern(IntegerPrimitive, "[0-9]+", func(tag string, tokenValue string) (Element, error) {
+			return NewStringElement(tokenValue), nil
+		})
+		lexer.AddCollectionPattern("(", ")", func(tag string, elements []Element) (Element, error) {
+			kept = append(kept, elements)
+			return NewList(elements...)
+		})
+
+		_, err = lexer.Parse("((1 2) (3 4))")
+		Ω(err).Should(BeNil())
+		Ω(kept).Should(HaveLen(3))
+		Ω(kept[0][0].Value()).Should(BeEquivalentTo("1"))
+		Ω(kept[1][0].Value()).Should(BeEquivalentTo("3"))
 	})
 
 	It("should initialize without issue", func() {
@@ -86,4 +180,101 @@ var _ = Describe("Lexer tests", func() {
 		Ω(elem).Should(BeNil())
 		Ω(err).Should(test.HaveMessage(ErrParserError))
 	})
+
+	It("should fail on malformed input", func() {
+		for _, data := range []string{
+			`"unterminated`, `1 2`, `]`, `(1]`, `#`, `#9a 1`, `#my/tag`, `#a #b 1`, `1a`, `\`, `[1 2`,
+		} {
+			elem, err := Parse(data)
+			Ω(err).Should(test.HaveMessage(ErrParserError), data)
+			Ω(elem).Should(BeNil(), data)
+		}
+	})
+
+	It("should parse blanks, comments and tags between the elements", func() {
+		elem, err := Parse("  ; leading comment\n[1,2 ;inner\n #my/tag[3] #my/tag   4 \"a;b\" \\a]")
+		Ω(err).Should(BeNil())
+
+		expected, err := Parse(`[1 2 #my/tag [3] #my/tag 4 "a;b" \a]`)
+		Ω(err).Should(BeNil())
+		Ω(elem.Equals(expected)).Should(BeTrue())
+	})
+})
+
+// benchmarkData builds a pull result like document with the given number of entities.
+func benchmarkData(entities int) string {
+	var buffer bytes.Buffer
+	buffer.WriteString("[")
+	for i := 0; i < entities; i++ {
+		fmt.Fprintf(&buffer, `{:db/id %d :book/title "Title number %d" :book/isbn "978-3-16-148410-%d" `, 17592186045418+i, i, i%10)
+		fmt.Fprintf(&buffer, `:book/price %d.99 :book/in-print true :book/tags #{:fiction :classic} `, i%100)
+		fmt.Fprintf(&buffer, `:book/author {:db/id %d :author/name "Author %d"} :book/ratings [1 2 3 4 5] `, i*2, i)
+		fmt.Fprintf(&buffer, `:book/published #inst "1985-04-12T23:20:50Z" :book/uuid #uuid "f81d4fae-7dec-11d0-a765-00a0c91e6bf6"}`)
+		buffer.WriteString("\n")
+	}
+	buffer.WriteString("]")
+	return buffer.String()
+}
+
+func BenchmarkParse(b *testing.B) {
+	data := benchmarkData(1000)
+	if _, err := Parse(data); err != nil {
+		b.Fatal(err)
+	}
+
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := Parse(data); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+var _ = Describe("Discarding elements", func() {
+
+	It("should discard the next element at any level", func() {
+		tests := map[string]string{
+			"#_ 1 2":                              "2",
+			"1 #_ 2":                              "1",
+			"#_1 2":                               "2",
+			"#_ #_ 1 2 3":                         "3",
+			"[1 #_ 2 3]":                          "[1 3]",
+			"[1 #_[2 [3]] 4 #_ 5]":                "[1 4]",
+			"(#_ :a)":                             "()",
+			"{:a 1 #_ :b :c 3}":                   "{:a 1 :c 3}",
+			"{:a #_ 1 2}":                         "{:a 2}",
+			"{:a 1 #_ {:b 2}}":                    "{:a 1}",
+			"#{1 #_ 2}":                           "#{1}",
+			"#my/tag #_ 1 2":                      "#my/tag 2",
+			"[#_ #inst \"1985-04-12T23:20:50Z\"]": "[]",
+			"[1 #_ ; comment\n 2 3]":              "[1 3]",
+		}
+
+		for data, expected := range tests {
+			elem, err := Parse(data)
+			Ω(err).Should(BeNil(), data)
+
+			expectedElem, err := Parse(expected)
+			Ω(err).Should(BeNil(), expected)
+			Ω(elem.Equals(expectedElem)).Should(BeTrue(), data)
+
+			str, err := elem.Serialize(EvaEdnMimeType)
+			Ω(err).Should(BeNil())
+			Ω(str).ShouldNot(ContainSubstring(DiscardPrefix), data)
+		}
+	})
+
+	It("should fail when there is nothing to discard", func() {
+		for _, data := range []string{"#_", "#_ 1", "[1 #_]", "#_ ]", "#_ [1"} {
+			elem, err := Parse(data)
+			Ω(err).Should(test.HaveMessage(ErrParserError), data)
+			Ω(elem).Should(BeNil(), data)
+		}
+
+		// the discarded value leaves the key without a value.
+		_, err := Parse("{:a #_ 1}")
+		Ω(err).Should(test.HaveMessage(ErrInvalidPair))
+	})
 })
@@ -57,7 +57,7 @@ func NewList(elements ...Element) (elem CollectionElement, err error) {
 		}
 
 		var base *baseElemImpl
-		if base, err = baseFactory().make(coll, ListType, collectionSerialization(false)); err == nil {
+		if base, err = makeCollectionBase(coll, ListType, false); err == nil {
 			coll.baseElemImpl = base
 			elem = coll
 			err = elem.Append(elements...)
@@ -72,7 +72,7 @@ func NewMap(pairs ...Pair) (elem CollectionElement, err error) {
 	}
 
 	var base *baseElemImpl
-	if base, err = baseFactory().make(coll, MapType, collectionSerialization(true)); err == nil {
+	if base, err = makeCollectionBase(coll, MapType, true); err == nil {
 		coll.baseElemImpl = base
 
 		// check for errors
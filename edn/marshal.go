@@ -0,0 +1,850 @@
+// Copyright 2018-2019 Workiva Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package edn
+
+import (
+	"math/big"
+	"net/url"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mattrobenolt/gocql/uuid"
+)
+
+const (
+
+	// ErrMarshal defines the error when a value cannot be converted into an element.
+	ErrMarshal = ErrorMessage("Unable to marshal value")
+
+	// ErrUnmarshal defines the error when an element cannot be converted into a value.
+	ErrUnmarshal = ErrorMessage("Unable to unmarshal element")
+
+	// tagName is the struct tag used to configure the marshalling of a field.
+	tagName = "edn"
+
+	// omitEmptyOption will skip the field if the value is empty.
+	omitEmptyOption = "omitempty"
+
+	// setOption will marshal a slice, array or map as a set.
+	setOption = "set"
+
+	// listOption will marshal a slice or array as a list.
+	listOption = "list"
+
+	// keywordOption will marshal a string as a keyword.
+	keywordOption = "keyword"
+
+	// symbolOption will marshal a string as a symbol.
+	symbolOption = "symbol"
+)
+
+// Marshaler is implemented by types that can convert themselves into an element.
+type Marshaler interface {
+
+	// MarshalEDN converts this value into an element.
+	MarshalEDN() (Element, error)
+}
+
+// Unmarshaler is implemented by types that can populate themselves from an element.
+type Unmarshaler interface {
+
+	// UnmarshalEDN populates this value from the element.
+	UnmarshalEDN(Element) error
+}
+
+var (
+	elementReflectType     = reflect.TypeOf((*Element)(nil)).Elem()
+	marshalerReflectType   = reflect.TypeOf((*Marshaler)(nil)).Elem()
+	unmarshalerReflectType = reflect.TypeOf((*Unmarshaler)(nil)).Elem()
+	timeReflectType        = reflect.TypeOf(time.Time{})
+	uuidReflectType        = reflect.TypeOf(uuid.UUID{})
+	bigIntReflectType      = reflect.TypeOf(big.Int{})
+	decimalReflectType     = reflect.TypeOf(Decimal{})
+	urlReflectType         = reflect.TypeOf(url.URL{})
+	bytesReflectType       = reflect.TypeOf([]byte(nil))
+)
+
+// fieldOptions holds the options found on a struct tag.
+type fieldOptions struct {
+	omitEmpty bool
+	asSet     bool
+	asList    bool
+	asKeyword bool
+	asSymbol  bool
+}
+
+// structField describes a single marshalled field of a struct.
+type structField struct {
+	index   []int
+	key     Element
+	options fieldOptions
+}
+
+// structFields caches the fields per struct type.
+var structFields sync.Map
+
+// Marshal converts the value into its EDN representation.
+//
+// Structs are converted to maps keyed by keywords. The key for each field is taken from the `edn` struct tag, for
+// example `edn:"book/title"` is the keyword :book/title. Fields without a tag use the field name, and a tag of "-"
+// skips the field. The tag may also carry the following options after the name:
+//
+//	omitempty - the field is skipped if it is the zero value.
+//	set       - a slice, array or map is marshalled as a set.
+//	list      - a slice or array is marshalled as a list.
+//	keyword   - a string is marshalled as a keyword.
+//	symbol    - a string is marshalled as a symbol.
+//
+// Slices and arrays become vectors, maps become maps and pointers are followed. Nil pointers, slices and maps become
+// nil.
+func Marshal(v interface{}) (data []byte, err error) {
+
+	var elem Element
+	if elem, err = MarshalElement(v); err == nil {
+		var str string
+		if str, err = elem.Serialize(EvaEdnMimeType); err == nil {
+			data = []byte(str)
+		}
+	}
+
+	return data, err
+}
+
+// MarshalElement converts the value into an element. See Marshal for the conversion rules.
+func MarshalElement(v interface{}) (Element, error) {
+	return marshalValue(reflect.ValueOf(v), fieldOptions{})
+}
+
+// Unmarshal parses the EDN data and stores the result in the value pointed to by v. See UnmarshalElement for the
+// conversion rules.
+func Unmarshal(data []byte, v interface{}) (err error) {
+
+	var elem Element
+	if elem, err = Parse(string(data)); err == nil {
+		err = UnmarshalElement(elem, v)
+	}
+
+	return err
+}
+
+// UnmarshalElement stores the element in the value pointed to by v. This follows the inverse of the Marshal rules.
+// When the target is an empty interface, the element is converted to the natural go type: nil, bool, int64,
+// float64, string, rune, time.Time, uuid.UUID, []interface{} for lists, vectors and sets and map[string]interface{}
+// for maps. Keywords and symbols are converted to their string form.
+func UnmarshalElement(elem Element, v interface{}) (err error) {
+
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Ptr && !rv.IsNil() {
+		err = unmarshalValue(elem, rv.Elem())
+	} else {
+		err = MakeErrorWithFormat(ErrUnmarshal, "target must be a non-nil pointer, got: %T", v)
+	}
+
+	return err
+}
+
+// parseFieldTag splits the struct tag into the name and options.
+func parseFieldTag(tag string) (name string, options fieldOptions) {
+
+	parts := strings.Split(tag, ",")
+	name = parts[0]
+	for _, option := range parts[1:] {
+		switch strings.TrimSpace(option) {
+		case omitEmptyOption:
+			options.omitEmpty = true
+		case setOption:
+			options.asSet = true
+		case listOption:
+			options.asList = true
+		case keywordOption:
+			options.asKeyword = true
+		case symbolOption:
+			options.asSymbol = true
+		}
+	}
+
+	return name, options
+}
+
+// appendStructFields will collect the fields for the struct type, embedded structs are flattened into the parent.
+func appendStructFields(fields []*structField, t reflect.Type, index []int) (_ []*structField, err error) {
+
+	for i := 0; i < t.NumField() && err == nil; i++ {
+		field := t.Field(i)
+		tag, hasTag := field.Tag.Lookup(tagName)
+
+		if tag == "-" {
+			continue
+		}
+
+		fieldIndex := append(append([]int{}, index...), i)
+
+		if field.Anonymous && !hasTag {
+			ft := field.Type
+			if ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+
+			if ft.Kind() == reflect.Struct {
+				fields, err = appendStructFields(fields, ft, fieldIndex)
+				continue
+			}
+		}
+
+		// unexported fields are not marshalled.
+		if len(field.PkgPath) != 0 {
+			continue
+		}
+
+		name, options := parseFieldTag(tag)
+		if len(name) == 0 {
+			name = field.Name
+		}
+
+		var key SymbolElement
+		if key, err = NewKeywordElement(name); err == nil {
+			fields = append(fields, &structField{
+				index:   fieldIndex,
+				key:     key,
+				options: options,
+			})
+		}
+	}
+
+	return fields, err
+}
+
+// getStructFields returns the cached fields for the struct type.
+func getStructFields(t reflect.Type) (fields []*structField, err error) {
+
+	if cached, has := structFields.Load(t); has {
+		fields = cached.([]*structField)
+	} else if fields, err = appendStructFields(nil, t, nil); err == nil {
+		structFields.Store(t, fields)
+	} else {
+		err = MakeErrorWithFormat(ErrMarshal, "type: %s - %s", t, err)
+	}
+
+	return fields, err
+}
+
+// fieldByIndex returns the field, allocating any nil embedded pointers along the way if alloc is set. A nil pointer
+// to an unexported embedded struct can not be allocated, which is an error.
+func fieldByIndex(v reflect.Value, index []int, alloc bool) (field reflect.Value, ok bool, err error) {
+
+	field = v
+	ok = true
+	for i, x := range index {
+		if i > 0 && field.Kind() == reflect.Ptr {
+			if field.IsNil() {
+				if !alloc {
+					ok = false
+					break
+				}
+				if !field.CanSet() {
+					ok = false
+					err = MakeErrorWithFormat(ErrUnmarshal, "cannot set the nil pointer to the unexported embedded struct: %s", field.Type().Elem())
+					break
+				}
+				field.Set(reflect.New(field.Type().Elem()))
+			}
+			field = field.Elem()
+		}
+		field = field.Field(x)
+	}
+
+	return field, ok, err
+}
+
+// isEmptyValue checks if the value is the zero value for omitempty.
+func isEmptyValue(v reflect.Value) (empty bool) {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		empty = v.Len() == 0
+	case reflect.Bool:
+		empty = !v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		empty = v.Int() == 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		empty = v.Uint() == 0
+	case reflect.Float32, reflect.Float64:
+		empty = v.Float() == 0
+	case reflect.Interface, reflect.Ptr:
+		empty = v.IsNil()
+	case reflect.Struct:
+		if v.Type() == timeReflectType {
+			empty = v.Interface().(time.Time).IsZero()
+		}
+	}
+	return empty
+}
+
+// marshalValue converts the reflected value into an element.
+func marshalValue(v reflect.Value, options fieldOptions) (elem Element, err error) {
+
+	if !v.IsValid() {
+		return NewNilElement(), nil
+	}
+
+	if v.Type().Implements(elementReflectType) {
+		if v.Kind() == reflect.Interface || v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				return NewNilElement(), nil
+			}
+		}
+		return v.Interface().(Element), nil
+	}
+
+	if v.Type().Implements(marshalerReflectType) {
+		if (v.Kind() == reflect.Interface || v.Kind() == reflect.Ptr) && v.IsNil() {
+			return NewNilElement(), nil
+		}
+		return v.Interface().(Marshaler).MarshalEDN()
+	}
+
+	// a marshaler with a pointer receiver is used when the value is addressable, e.g. a struct field.
+	if v.Kind() != reflect.Ptr && v.CanAddr() && v.Addr().Type().Implements(marshalerReflectType) && v.Addr().CanInterface() {
+		return v.Addr().Interface().(Marshaler).MarshalEDN()
+	}
+
+	if v.CanInterface() {
+		if tagged, has, e := writeTagged(v.Interface()); has {
+			return tagged, e
+		}
+	}
+
+	switch v.Type() {
+	case timeReflectType:
+		return NewInstantElement(v.Interface().(time.Time)), nil
+	case uuidReflectType:
+		return NewUUIDElement(v.Interface().(uuid.UUID)), nil
+	case bigIntReflectType:
+		i := reflect.New(bigIntReflectType)
+		i.Elem().Set(v)
+		return NewBigIntElement(i.Interface().(*big.Int))
+	case decimalReflectType:
+		dec := v.Interface().(Decimal)
+		return NewBigDecElement(&dec)
+	case urlReflectType:
+		u := v.Interface().(url.URL)
+		return NewURIElement(&u), nil
+	case bytesReflectType:
+		if v.IsNil() {
+			return NewNilElement(), nil
+		}
+		return NewBytesElement(v.Bytes()), nil
+	}
+
+	switch v.Kind() {
+	case reflect.Interface, reflect.Ptr:
+		if v.IsNil() {
+			elem = NewNilElement()
+		} else {
+			elem, err = marshalValue(v.Elem(), options)
+		}
+	case reflect.Bool:
+		elem = NewBooleanElement(v.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		elem = NewIntegerElement(v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if u := v.Uint(); u <= 1<<63-1 {
+			elem = NewIntegerElement(int64(u))
+		} else {
+			err = MakeErrorWithFormat(ErrMarshal, "integer overflow: %d", u)
+		}
+	case reflect.Float32:
+		elem = NewFloatElement(v.Float())
+	case reflect.Float64:
+		elem = NewDoubleElement(v.Float())
+	case reflect.String:
+		switch {
+		case options.asKeyword:
+			elem, err = NewKeywordElement(v.String())
+		case options.asSymbol:
+			elem, err = NewSymbolElement(v.String())
+		default:
+			elem = NewStringElement(v.String())
+		}
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			elem = NewNilElement()
+		} else {
+			elem, err = marshalSequence(v, options)
+		}
+	case reflect.Map:
+		if v.IsNil() {
+			elem = NewNilElement()
+		} else if options.asSet {
+			elem, err = marshalMapAsSet(v)
+		} else {
+			elem, err = marshalMap(v)
+		}
+	case reflect.Struct:
+		elem, err = marshalStruct(v)
+	default:
+		err = MakeErrorWithFormat(ErrMarshal, "unsupported type: %s", v.Type())
+	}
+
+	return elem, err
+}
+
+// marshalSequence converts a slice or array into a vector, list or set.
+func marshalSequence(v reflect.Value, options fieldOptions) (elem Element, err error) {
+
+	children := make([]Element, 0, v.Len())
+	childOptions := fieldOptions{asKeyword: options.asKeyword, asSymbol: options.asSymbol}
+	for i := 0; i < v.Len() && err == nil; i++ {
+		var child Element
+		if child, err = marshalValue(v.Index(i), childOptions); err == nil {
+			children = append(children, child)
+		}
+	}
+
+	if err == nil {
+		switch {
+		case options.asSet:
+			elem, err = NewSet(children...)
+		case options.asList:
+			elem, err = NewList(children...)
+		default:
+			elem, err = NewVector(children...)
+		}
+	}
+
+	return elem, err
+}
+
+// marshalMap converts a go map into a map element.
+func marshalMap(v reflect.Value) (elem Element, err error) {
+
+	var coll CollectionElement
+	if coll, err = NewMap(); err == nil {
+		iter := v.MapRange()
+		for iter.Next() && err == nil {
+			var key, value Element
+			if key, err = marshalValue(iter.Key(), fieldOptions{}); err == nil {
+				if value, err = marshalValue(iter.Value(), fieldOptions{}); err == nil {
+					err = coll.Append(key, value)
+				}
+			}
+		}
+	}
+
+	if err == nil {
+		elem = coll
+	}
+
+	return elem, err
+}
+
+// marshalMapAsSet converts a go map into a set element, only keys with a non-zero value are added. This is useful for
+// the map[T]bool and map[T]struct{} idioms.
+func marshalMapAsSet(v reflect.Value) (elem Element, err error) {
+
+	var coll CollectionElement
+	if coll, err = NewSet(); err == nil {
+		iter := v.MapRange()
+		for iter.Next() && err == nil {
+			if value := iter.Value(); value.Kind() != reflect.Bool || value.Bool() {
+				var key Element
+				if key, err = marshalValue(iter.Key(), fieldOptions{}); err == nil {
+					err = coll.Append(key)
+				}
+			}
+		}
+	}
+
+	if err == nil {
+		elem = coll
+	}
+
+	return elem, err
+}
+
+// marshalStruct converts a struct into a map element keyed by keywords.
+func marshalStruct(v reflect.Value) (elem Element, err error) {
+
+	var fields []*structField
+	var coll CollectionElement
+	if fields, err = getStructFields(v.Type()); err == nil {
+		if coll, err = NewMap(); err == nil {
+			for _, field := range fields {
+				fv, ok, _ := fieldByIndex(v, field.index, false)
+				if !ok || (field.options.omitEmpty && isEmptyValue(fv)) {
+					continue
+				}
+
+				var value Element
+				if value, err = marshalValue(fv, field.options); err == nil {
+					err = coll.Append(field.key, value)
+				}
+
+				if err != nil {
+					break
+				}
+			}
+		}
+	}
+
+	if err == nil {
+		elem = coll
+	}
+
+	return elem, err
+}
+
+// unmarshalValue stores the element in the settable value.
+func unmarshalValue(elem Element, v reflect.Value) (err error) {
+
+	if elem == nil {
+		elem = NewNilElement()
+	}
+
+	// handle the types that want the element directly.
+	if v.Kind() == reflect.Interface && v.Type().Implements(elementReflectType) {
+		if ev := reflect.ValueOf(elem); ev.Type().AssignableTo(v.Type()) {
+			v.Set(ev)
+		} else if elem.ElementType() == NilType {
+			v.Set(reflect.Zero(v.Type()))
+		} else {
+			err = unmarshalTypeError(elem, v)
+		}
+		return err
+	}
+
+	if v.CanAddr() && v.Addr().Type().Implements(unmarshalerReflectType) {
+		return v.Addr().Interface().(Unmarshaler).UnmarshalEDN(elem)
+	}
+
+	if elem.ElementType() == NilType {
+		switch v.Kind() {
+		case reflect.Interface, reflect.Ptr, reflect.Map, reflect.Slice:
+			v.Set(reflect.Zero(v.Type()))
+		}
+		return nil
+	}
+
+	switch v.Type() {
+	case timeReflectType:
+		if t, is := elem.Value().(time.Time); is {
+			v.Set(reflect.ValueOf(t))
+		} else {
+			err = unmarshalTypeError(elem, v)
+		}
+		return err
+	case uuidReflectType:
+		if id, is := elem.Value().(uuid.UUID); is {
+			v.Set(reflect.ValueOf(id))
+		} else {
+			err = unmarshalTypeError(elem, v)
+		}
+		return err
+	case bigIntReflectType:
+		switch val := elem.Value().(type) {
+		case *big.Int:
+			v.Set(reflect.ValueOf(new(big.Int).Set(val)).Elem())
+		case int64:
+			v.Set(reflect.ValueOf(big.NewInt(val)).Elem())
+		default:
+			err = unmarshalTypeError(elem, v)
+		}
+		return err
+	case decimalReflectType:
+		switch val := elem.Value().(type) {
+		case *Decimal:
+			v.Set(reflect.ValueOf(*NewDecimal(val.unscaled, val.scale)))
+		case *big.Int:
+			v.Set(reflect.ValueOf(*NewDecimal(val, 0)))
+		case int64:
+			v.Set(reflect.ValueOf(*NewDecimal(big.NewInt(val), 0)))
+		default:
+			err = unmarshalTypeError(elem, v)
+		}
+		return err
+	case urlReflectType:
+		switch val := elem.Value().(type) {
+		case *url.URL:
+			v.Set(reflect.ValueOf(*val))
+		case string:
+			var u *url.URL
+			if u, err = url.Parse(val); err == nil {
+				v.Set(reflect.ValueOf(*u))
+			} else {
+				err = unmarshalTypeError(elem, v)
+			}
+		default:
+			err = unmarshalTypeError(elem, v)
+		}
+		return err
+	case bytesReflectType:
+		if data, is := elem.Value().([]byte); is {
+			v.SetBytes(append([]byte{}, data...))
+			return nil
+		}
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		err = unmarshalValue(elem, v.Elem())
+	case reflect.Interface:
+		if v.NumMethod() == 0 {
+			var natural interface{}
+			if natural, err = naturalValue(elem); err == nil {
+				if natural == nil {
+					v.Set(reflect.Zero(v.Type()))
+				} else {
+					v.Set(reflect.ValueOf(natural))
+				}
+			}
+		} else {
+			err = unmarshalTypeError(elem, v)
+		}
+	case reflect.Bool:
+		if b, is := elem.Value().(bool); is {
+			v.SetBool(b)
+		} else {
+			err = unmarshalTypeError(elem, v)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		var i int64
+		switch val := elem.Value().(type) {
+		case int64:
+			i = val
+		case rune:
+			i = int64(val)
+		case *big.Int:
+			if val.IsInt64() {
+				i = val.Int64()
+			} else {
+				err = MakeErrorWithFormat(ErrUnmarshal, "%s overflows %s", val, v.Type())
+			}
+		default:
+			err = unmarshalTypeError(elem, v)
+		}
+
+		if err == nil {
+			if v.OverflowInt(i) {
+				err = MakeErrorWithFormat(ErrUnmarshal, "%d overflows %s", i, v.Type())
+			} else {
+				v.SetInt(i)
+			}
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if i, is := elem.Value().(int64); is && i >= 0 && !v.OverflowUint(uint64(i)) {
+			v.SetUint(uint64(i))
+		} else {
+			err = unmarshalTypeError(elem, v)
+		}
+	case reflect.Float32, reflect.Float64:
+		switch val := elem.Value().(type) {
+		case float64:
+			v.SetFloat(val)
+		case int64:
+			v.SetFloat(float64(val))
+		case *Decimal:
+			v.SetFloat(val.Float64())
+		default:
+			err = unmarshalTypeError(elem, v)
+		}
+	case reflect.String:
+		var str string
+		if str, err = stringValue(elem); err == nil {
+			v.SetString(str)
+		} else {
+			err = unmarshalTypeError(elem, v)
+		}
+	case reflect.Slice, reflect.Array:
+		err = unmarshalSequence(elem, v)
+	case reflect.Map:
+		err = unmarshalMap(elem, v)
+	case reflect.Struct:
+		err = unmarshalStruct(elem, v)
+	default:
+		err = unmarshalTypeError(elem, v)
+	}
+
+	return err
+}
+
+// unmarshalTypeError creates the error for an element that cannot be stored in the value.
+func unmarshalTypeError(elem Element, v reflect.Value) error {
+	return MakeErrorWithFormat(ErrUnmarshal, "cannot store %s into %s", elem.ElementType().Name(), v.Type())
+}
+
+// stringValue returns the string form of string like elements.
+func stringValue(elem Element) (str string, err error) {
+	switch elem.ElementType() {
+	case StringType:
+		str = elem.Value().(string)
+	case KeywordType, SymbolType:
+		sym := elem.(SymbolElement)
+		str = sym.AppendNameOntoNamespace(sym.Name())
+	case CharacterType:
+		str = string(elem.Value().(rune))
+	default:
+		err = MakeError(ErrUnmarshal, elem.ElementType().Name())
+	}
+	return str, err
+}
+
+// naturalValue converts the element into the natural go type.
+func naturalValue(elem Element) (value interface{}, err error) {
+
+	switch elem.ElementType() {
+	case KeywordType, SymbolType:
+		value, err = stringValue(elem)
+	case ListType, VectorType, SetType:
+		items := make([]interface{}, 0, elem.(CollectionElement).Len())
+		err = elem.(CollectionElement).IterateChildren(func(_ Element, child Element) (e error) {
+			var item interface{}
+			if item, e = naturalValue(child); e == nil {
+				items = append(items, item)
+			}
+			return e
+		})
+		value = items
+	case MapType:
+		items := make(map[string]interface{}, elem.(CollectionElement).Len())
+		err = elem.(CollectionElement).IterateChildren(func(key Element, child Element) (e error) {
+			var k string
+			if k, e = stringValue(key); e != nil {
+				k, e = key.Serialize(EvaEdnMimeType)
+			}
+
+			if e == nil {
+				var item interface{}
+				if item, e = naturalValue(child); e == nil {
+					items[k] = item
+				}
+			}
+			return e
+		})
+		value = items
+	default:
+		value = elem.Value()
+	}
+
+	return value, err
+}
+
+// unmarshalSequence stores the children of a list, vector or set into a slice or array.
+func unmarshalSequence(elem Element, v reflect.Value) (err error) {
+
+	switch elem.ElementType() {
+	case ListType, VectorType, SetType:
+		coll := elem.(CollectionElement)
+		if v.Kind() == reflect.Slice {
+			v.Set(reflect.MakeSlice(v.Type(), coll.Len(), coll.Len()))
+		} else if v.Len() < coll.Len() {
+			return MakeErrorWithFormat(ErrUnmarshal, "%d items do not fit into %s", coll.Len(), v.Type())
+		}
+
+		index := 0
+		err = coll.IterateChildren(func(_ Element, child Element) (e error) {
+			e = unmarshalValue(child, v.Index(index))
+			index++
+			return e
+		})
+
+		// Zero the rest of an array, as encoding/json does.
+		for ; err == nil && index < v.Len(); index++ {
+			v.Index(index).Set(reflect.Zero(v.Type().Elem()))
+		}
+	default:
+		err = unmarshalTypeError(elem, v)
+	}
+
+	return err
+}
+
+// unmarshalMap stores a map into a go map, or a set into a go map where each member is a key set to true.
+func unmarshalMap(elem Element, v reflect.Value) (err error) {
+
+	switch elem.ElementType() {
+	case MapType, SetType:
+		coll := elem.(CollectionElement)
+		if v.IsNil() {
+			v.Set(reflect.MakeMapWithSize(v.Type(), coll.Len()))
+		}
+
+		isSet := elem.ElementType() == SetType
+		err = coll.IterateChildren(func(key Element, child Element) (e error) {
+			k := reflect.New(v.Type().Key()).Elem()
+			val := reflect.New(v.Type().Elem()).Elem()
+
+			if isSet {
+				if e = unmarshalValue(child, k); e == nil {
+					switch val.Kind() {
+					case reflect.Bool:
+						val.SetBool(true)
+					case reflect.Struct:
+					default:
+						e = unmarshalTypeError(elem, v)
+					}
+				}
+			} else if e = unmarshalValue(key, k); e == nil {
+				e = unmarshalValue(child, val)
+			}
+
+			if e == nil {
+				v.SetMapIndex(k, val)
+			}
+			return e
+		})
+	default:
+		err = unmarshalTypeError(elem, v)
+	}
+
+	return err
+}
+
+// unmarshalStruct stores the map into the struct, matching keys to the field keywords.
+func unmarshalStruct(elem Element, v reflect.Value) (err error) {
+
+	if elem.ElementType() != MapType {
+		return unmarshalTypeError(elem, v)
+	}
+
+	var fields []*structField
+	if fields, err = getStructFields(v.Type()); err == nil {
+		coll := elem.(CollectionElement)
+		for _, field := range fields {
+			var child Element
+			if child, err = coll.Get(field.key); err != nil {
+				if ErrNoValue.IsEquivalent(err) {
+					err = nil
+					continue
+				}
+				break
+			}
+
+			var fv reflect.Value
+			if fv, _, err = fieldByIndex(v, field.index, true); err == nil {
+				err = unmarshalValue(child, fv)
+			}
+
+			if err != nil {
+				break
+			}
+		}
+	}
+
+	return err
+}
@@ -0,0 +1,279 @@
+// Copyright 2018-2019 Workiva Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package edn
+
+import (
+	"time"
+
+	"github.com/Workiva/eva-client-go/test"
+	"github.com/mattrobenolt/gocql/uuid"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type marshalAuthor struct {
+	Name string `edn:"author/name"`
+}
+
+type marshalAudit struct {
+	Created time.Time `edn:"audit/created,omitempty"`
+}
+
+type marshalBook struct {
+	marshalAudit
+	ID      uuid.UUID       `edn:"book/id"`
+	Title   string          `edn:"book/title"`
+	Year    int             `edn:"book/year,omitempty"`
+	Tags    []string        `edn:"book/tags,set"`
+	Genre   string          `edn:"book/genre,keyword,omitempty"`
+	Author  *marshalAuthor  `edn:"book/author,omitempty"`
+	Ratings map[string]int  `edn:"book/ratings,omitempty"`
+	Extra   Element         `edn:"book/extra,omitempty"`
+	Ignored string          `edn:"-"`
+	Flags   map[string]bool `edn:"book/flags,set,omitempty"`
+	secret  string
+}
+
+type marshalRoundTrip struct {
+	Title  string         `edn:"book/title"`
+	Year   int64          `edn:"book/year"`
+	Tags   []string       `edn:"book/tags,list"`
+	Author *marshalAuthor `edn:"book/author"`
+	Genre  string         `edn:"book/genre,symbol"`
+}
+
+type marshalCustom struct {
+	value string
+}
+
+func (c marshalCustom) MarshalEDN() (Element, error) {
+	return NewSymbolElement(c.value)
+}
+
+func (c *marshalCustom) UnmarshalEDN(elem Element) (err error) {
+	c.value, err = stringValue(elem)
+	return err
+}
+
+type marshalPointer struct {
+	value int64
+}
+
+func (c *marshalPointer) MarshalEDN() (Element, error) {
+	return NewIntegerElement(c.value * 10), nil
+}
+
+type marshalPointerHolder struct {
+	Value marshalPointer `edn:"value"`
+}
+
+type MarshalExportedAudit struct {
+	Note string `edn:"audit/note"`
+}
+
+type marshalEmbeddedPointers struct {
+	*marshalAudit
+	*MarshalExportedAudit
+	Title string `edn:"book/title"`
+}
+
+var _ = Describe("Marshal in EDN", func() {
+	Context("marshalling", func() {
+
+		It("should marshal primitives", func() {
+			for value, expected := range map[interface{}]string{
+				true:         "true",
+				int8(-3):     "-3",
+				uint16(42):   "42",
+				"foo":        `"foo"`,
+				nil:          "nil",
+				(*int)(nil):  "nil",
+				[2]int{1, 2}: "[1 2]",
+				float32(1.5): "1.5",
+				float32(0.1): "0.1",
+				float64(0.1): "0.1",
+				float64(3):   "3.0",
+			} {
+				data, err := Marshal(value)
+				Ω(err).Should(BeNil())
+				Ω(string(data)).Should(BeEquivalentTo(expected))
+			}
+		})
+
+		It("should marshal a struct using the tags", func() {
+			author := &marshalAuthor{Name: "Herbert"}
+			book := marshalBook{
+				Title:   "Dune",
+				Tags:    []string{"scifi"},
+				Genre:   "genre/scifi",
+				Author:  author,
+				Ignored: "ignored",
+				secret:  "secret",
+			}
+
+			elem, err := MarshalElement(book)
+			Ω(err).Should(BeNil())
+			Ω(elem.ElementType()).Should(BeEquivalentTo(MapType))
+
+			coll := elem.(CollectionElement)
+			Ω(coll.Len()).Should(BeEquivalentTo(5))
+
+			title, err := coll.Get(":book/title")
+			Ω(err).Should(BeNil())
+			Ω(title.Value()).Should(BeEquivalentTo("Dune"))
+
+			tags, err := coll.Get(":book/tags")
+			Ω(err).Should(BeNil())
+			Ω(tags.ElementType()).Should(BeEquivalentTo(SetType))
+
+			genre, err := coll.Get(":book/genre")
+			Ω(err).Should(BeNil())
+			Ω(genre.ElementType()).Should(BeEquivalentTo(KeywordType))
+
+			authorElem, err := coll.Get(":book/author")
+			Ω(err).Should(BeNil())
+			Ω(authorElem.String()).Should(BeEquivalentTo(`{:author/name "Herbert"}`))
+
+			_, err = coll.Get(":book/year")
+			Ω(err).Should(test.HaveMessage(ErrNoValue))
+		})
+
+		It("should use the marshaler interface", func() {
+			data, err := Marshal([]marshalCustom{{"foo"}, {"bar/baz"}})
+			Ω(err).Should(BeNil())
+			Ω(string(data)).Should(BeEquivalentTo("[foo bar/baz]"))
+		})
+
+		It("should use a pointer receiver marshaler for addressable values", func() {
+			data, err := Marshal([]marshalPointer{{1}, {2}})
+			Ω(err).Should(BeNil())
+			Ω(string(data)).Should(BeEquivalentTo("[10 20]"))
+
+			data, err = Marshal(&marshalPointerHolder{Value: marshalPointer{3}})
+			Ω(err).Should(BeNil())
+			Ω(string(data)).Should(BeEquivalentTo("{:value 30}"))
+		})
+
+		It("should error on unsupported types", func() {
+			_, err := Marshal(make(chan int))
+			Ω(err).Should(test.HaveMessage(ErrMarshal))
+
+			_, err = Marshal(uint64(1 << 63))
+			Ω(err).Should(test.HaveMessage(ErrMarshal))
+		})
+	})
+
+	Context("unmarshalling", func() {
+
+		It("should unmarshal a struct", func() {
+			id := uuid.RandomUUID()
+			data := `{:book/id #uuid "` + id.String() + `" :book/title "Dune" :book/year 1965 :book/tags #{"scifi" "classic"}
+                      :book/genre :genre/scifi :book/author {:author/name "Herbert"} :book/ratings {"good" 5}
+                      :book/extra [1 2] :audit/created #inst "1985-04-12T23:20:50Z" :book/flags #{"a"} :unknown 1}`
+
+			book := marshalBook{}
+			err := Unmarshal([]byte(data), &book)
+			Ω(err).Should(BeNil())
+			Ω(book.ID).Should(BeEquivalentTo(id))
+			Ω(book.Title).Should(BeEquivalentTo("Dune"))
+			Ω(book.Year).Should(BeEquivalentTo(1965))
+			Ω(book.Tags).Should(ConsistOf("scifi", "classic"))
+			Ω(book.Genre).Should(BeEquivalentTo(":genre/scifi"))
+			Ω(book.Author).ShouldNot(BeNil())
+			Ω(book.Author.Name).Should(BeEquivalentTo("Herbert"))
+			Ω(book.Ratings).Should(HaveKeyWithValue("good", 5))
+			Ω(book.Extra.ElementType()).Should(BeEquivalentTo(VectorType))
+			Ω(book.Created.Year()).Should(BeEquivalentTo(1985))
+			Ω(book.Flags).Should(HaveKeyWithValue("a", true))
+		})
+
+		It("should unmarshal into the natural types", func() {
+			var value interface{}
+			err := Unmarshal([]byte(`{:a [1 "two" :three] "b" #{nil} 1 2.5}`), &value)
+			Ω(err).Should(BeNil())
+			Ω(value).Should(BeEquivalentTo(map[string]interface{}{
+				":a": []interface{}{int64(1), "two", ":three"},
+				"b":  []interface{}{nil},
+				"1":  2.5,
+			}))
+		})
+
+		It("should use the unmarshaler interface", func() {
+			var values []*marshalCustom
+			err := Unmarshal([]byte(`[foo :bar]`), &values)
+			Ω(err).Should(BeNil())
+			Ω(values).Should(HaveLen(2))
+			Ω(values[0].value).Should(BeEquivalentTo("foo"))
+			Ω(values[1].value).Should(BeEquivalentTo(":bar"))
+		})
+
+		It("should allocate embedded pointers it can set", func() {
+			var book marshalEmbeddedPointers
+			Ω(Unmarshal([]byte(`{:book/title "Dune" :audit/note "new"}`), &book)).Should(BeNil())
+			Ω(book.Title).Should(Equal("Dune"))
+			Ω(book.MarshalExportedAudit).ShouldNot(BeNil())
+			Ω(book.Note).Should(Equal("new"))
+		})
+
+		It("should error on nil unexported embedded pointers", func() {
+			var book marshalEmbeddedPointers
+			err := Unmarshal([]byte(`{:book/title "Dune" :audit/created #inst "1985-04-12T23:20:50Z"}`), &book)
+			Ω(err).Should(test.HaveMessage(ErrUnmarshal))
+
+			book.marshalAudit = &marshalAudit{}
+			Ω(Unmarshal([]byte(`{:audit/created #inst "1985-04-12T23:20:50Z"}`), &book)).Should(BeNil())
+			Ω(book.Created.Year()).Should(BeEquivalentTo(1985))
+		})
+
+		It("should zero the rest of an array", func() {
+			arr := [3]int{7, 8, 9}
+			Ω(Unmarshal([]byte(`[1]`), &arr)).Should(BeNil())
+			Ω(arr).Should(Equal([3]int{1, 0, 0}))
+		})
+
+		It("should reject bad targets and mismatched types", func() {
+			var i int8
+			Ω(Unmarshal([]byte(`1`), i)).Should(test.HaveMessage(ErrUnmarshal))
+			Ω(Unmarshal([]byte(`"foo"`), &i)).Should(test.HaveMessage(ErrUnmarshal))
+			Ω(Unmarshal([]byte(`1000`), &i)).Should(test.HaveMessage(ErrUnmarshal))
+
+			var arr [1]int
+			Ω(Unmarshal([]byte(`[1 2]`), &arr)).Should(test.HaveMessage(ErrUnmarshal))
+
+			var coll CollectionElement
+			Ω(Unmarshal([]byte(`1`), &coll)).Should(test.HaveMessage(ErrUnmarshal))
+			Ω(Unmarshal([]byte(`[1]`), &coll)).Should(BeNil())
+			Ω(coll.Len()).Should(BeEquivalentTo(1))
+		})
+
+		It("should round trip a struct", func() {
+			book := marshalRoundTrip{
+				Title:  "Dune",
+				Year:   1965,
+				Tags:   []string{"scifi"},
+				Author: &marshalAuthor{Name: "Herbert"},
+				Genre:  "genre/scifi",
+			}
+
+			data, err := Marshal(book)
+			Ω(err).Should(BeNil())
+
+			other := marshalRoundTrip{}
+			err = Unmarshal(data, &other)
+			Ω(err).Should(BeNil())
+			Ω(other).Should(BeEquivalentTo(book))
+		})
+	})
+})
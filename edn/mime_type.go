@@ -18,6 +18,12 @@ const (
 
 	// EvaEdnMimeType defines the mime type for the eva edn data.
 	EvaEdnMimeType SerializerMimeType = "application/vnd.eva+edn"
+
+	// JSONMimeType defines the mime type for the json data.
+	JSONMimeType SerializerMimeType = "application/json"
+
+	// TransitMimeType defines the mime type for the transit json data.
+	TransitMimeType SerializerMimeType = "application/transit+json"
 )
 
 type SerializerMimeType string
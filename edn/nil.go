@@ -44,6 +44,10 @@ func NewNilElement() (elem Element) {
 				out = TagPrefix + tag + " "
 			}
 			out += "nil"
+		case JSONMimeType:
+			out = jsonTagged(tag, "null")
+		case TransitMimeType:
+			out, e = transitString(tag, value)
 		default:
 			e = MakeError(ErrUnknownMimeType, serializer.MimeType())
 		}
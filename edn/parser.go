@@ -14,10 +14,97 @@
 
 package edn
 
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
 const (
 	ErrParserError = ErrorMessage("Parser error")
+
+	// snippetRadius is the number of bytes of input kept on either side of the failure in the snippet.
+	snippetRadius = 20
 )
 
+// ParseError describes where the parser failed. Syntax errors have the ErrParserError message, errors returned while
+// creating an element, such as an invalid keyword, keep their own message and are returned by Unwrap so that
+// errors.Is and errors.As can reach them.
+type ParseError struct {
+
+	// Line of the failure, starting at 1.
+	Line int
+
+	// Column of the failure in runes, starting at 1.
+	Column int
+
+	// Offset of the failure in bytes.
+	Offset int
+
+	// Expected describes the token that was expected, it is empty when the token was read but the element could not
+	// be created.
+	Expected string
+
+	// Snippet holds the input around the failure, it does not cross lines.
+	Snippet string
+
+	message ErrorMessage
+	details string
+	cause   error
+}
+
+// newParseError creates the parse error at the offset into the data.
+func newParseError(message ErrorMessage, details string, data string, offset int, expected string, cause error) *ParseError {
+
+	lineStart := strings.LastIndexByte(data[:offset], '\n') + 1
+	lineEnd := len(data)
+	if index := strings.IndexByte(data[offset:], '\n'); index != -1 {
+		lineEnd = offset + index
+	}
+
+	from := lineStart
+	if offset-snippetRadius > from {
+		for from = offset - snippetRadius; !utf8.RuneStart(data[from]); from++ {
+		}
+	}
+
+	to := lineEnd
+	if offset+snippetRadius < to {
+		for to = offset + snippetRadius; !utf8.RuneStart(data[to]); to-- {
+		}
+	}
+
+	return &ParseError{
+		Line:     strings.Count(data[:lineStart], "\n") + 1,
+		Column:   utf8.RuneCountInString(data[lineStart:offset]) + 1,
+		Offset:   offset,
+		Expected: expected,
+		Snippet:  data[from:to],
+		message:  message,
+		details:  details,
+		cause:    cause,
+	}
+}
+
+// Message will get the message part.
+func (e *ParseError) Message() string {
+	return e.message.Message()
+}
+
+// Error returns the error message with the position of the failure.
+func (e *ParseError) Error() string {
+	out := fmt.Sprintf("[%s]: %s at line %d, column %d", e.message, e.details, e.Line, e.Column)
+	if len(e.Expected) > 0 {
+		out += ", expected " + e.Expected
+	}
+	return out + fmt.Sprintf(": %q", e.Snippet)
+}
+
+// Unwrap returns the error returned while creating the element, if any.
+func (e *ParseError) Unwrap() error {
+	return e.cause
+}
+
 // globalLexer holds the global lexer.
 var globalLexer Lexer
 
@@ -316,3 +316,84 @@ var _ = Describe("Collection Parser", func() {
 		Ω(err).Should(test.HaveMessage(ErrParserError))
 	})
 })
+
+var _ = Describe("Parse errors", func() {
+
+	parseError := func(data string) *ParseError {
+		_, err := Parse(data)
+		Ω(err).ShouldNot(BeNil(), data)
+
+		parseErr, is := err.(*ParseError)
+		Ω(is).Should(BeTrue(), data)
+		return parseErr
+	}
+
+	It("should point to the failure", func() {
+		err := parseError("[:db/ident :person/name\n :db/valueType (:db.type/string]\n :db/doc \"name\"]")
+		Ω(err).Should(test.HaveMessage(ErrParserError))
+		Ω(ErrParserError.IsEquivalent(err)).Should(BeTrue())
+		Ω(err.Line).Should(BeEquivalentTo(2))
+		Ω(err.Column).Should(BeEquivalentTo(32))
+		Ω(err.Offset).Should(BeEquivalentTo(55))
+		Ω(err.Expected).Should(BeEquivalentTo("')'"))
+		Ω(err.Snippet).Should(BeEquivalentTo("ype (:db.type/string]"))
+		Ω(err.Error()).Should(ContainSubstring("at line 2, column 32, expected ')'"))
+	})
+
+	It("should count the columns in runes", func() {
+		err := parseError("[1 2 ; é")
+		Ω(err.Line).Should(BeEquivalentTo(1))
+		Ω(err.Column).Should(BeEquivalentTo(9))
+		Ω(err.Offset).Should(BeEquivalentTo(9))
+	})
+
+	It("should describe the expected token", func() {
+		tests := map[string]string{
+			"":              expectedElement,
+			"[1 2":          "']'",
+			"1 2":           expectedEnd,
+			"#9a 1":         expectedTag,
+			"#my/tag":       expectedElement,
+			"\"open":        `'"'`,
+			"[1 @ 2]":       expectedElement,
+			"   )":          expectedElement,
+			"#{1 2 3)":      "'}'",
+			"{:a 1} {:b 2}": expectedEnd,
+		}
+
+		for data, expected := range tests {
+			Ω(parseError(data).Expected).Should(BeEquivalentTo(expected), data)
+		}
+	})
+
+	It("should keep the message of the element errors", func() {
+		err := parseError("[1\n :a/]")
+		Ω(err).Should(test.HaveMessage(ErrInvalidKeyword))
+		Ω(err.Line).Should(BeEquivalentTo(2))
+		Ω(err.Column).Should(BeEquivalentTo(2))
+		Ω(err.Expected).Should(BeEmpty())
+		Ω(err.Unwrap()).ShouldNot(BeNil())
+
+		var cause *Error
+		Ω(errors.As(err, &cause)).Should(BeTrue())
+		Ω(cause).Should(test.HaveMessage(ErrInvalidKeyword))
+	})
+
+	It("should unwrap the errors from the tag readers", func() {
+		failure := errors.New("bad reader")
+		Ω(RegisterTagReader("test/fails", func(Element) (Element, error) { return nil, failure })).Should(BeNil())
+		defer func() { Ω(RegisterTagReader("test/fails", nil)).Should(BeNil()) }()
+
+		err := parseError("[1 #test/fails 2]")
+		Ω(errors.Is(err, failure)).Should(BeTrue())
+		Ω(err.Column).Should(BeEquivalentTo(16))
+	})
+
+	It("should limit the snippet", func() {
+		err := parseError("[" + strings.Repeat("a ", 30) + ")" + strings.Repeat(" b", 30))
+		Ω(err.Offset).Should(BeEquivalentTo(61))
+		Ω(err.Snippet).Should(HaveLen(2 * snippetRadius))
+		Ω(err.Snippet).Should(HavePrefix("a a"))
+		Ω(err.Snippet).Should(ContainSubstring("a ) b"))
+	})
+})
@@ -0,0 +1,333 @@
+// Copyright 2018-2019 Workiva Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package edn
+
+import (
+	"math"
+	"math/big"
+	"time"
+
+	"github.com/mattrobenolt/gocql/uuid"
+)
+
+const (
+
+	// ErrInvalidSelector defines the error for selectors that can not be compiled.
+	ErrInvalidSelector = ErrorMessage("Invalid selector")
+
+	// ErrUnexpectedType defines the error for an element that is not of the type asked for.
+	ErrUnexpectedType = ErrorMessage("Unexpected element type")
+
+	// WildcardSelector selects all the values of a collection.
+	WildcardSelector = "*"
+
+	// DescendantSelector selects the element and all the elements nested within it.
+	DescendantSelector = "**"
+)
+
+// Match is an element found by a selector.
+type Match struct {
+
+	// Path holds the keys from the root to the element, the keys of lists and vectors are their indexes.
+	Path []Element
+
+	// Element that was found.
+	Element Element
+}
+
+// GetIn returns the element at the end of the path of keys, each key is looked up as by CollectionElement.Get. A nil
+// element, at the start or along the path, is reported as ErrNoValue.
+func GetIn(elem Element, path ...interface{}) (value Element, err error) {
+
+	value = elem
+	for _, key := range path {
+		switch coll, is := value.(CollectionElement); {
+		case value == nil:
+			return nil, MakeErrorWithFormat(ErrNoValue, "%v: the element is nil", key)
+		case is:
+			if value, err = coll.Get(key); err != nil {
+				return nil, err
+			}
+		default:
+			return nil, MakeErrorWithFormat(ErrNoValue, "%v: %s is not a collection", key, value.ElementType().Name())
+		}
+	}
+
+	if value == nil {
+		err = MakeError(ErrNoValue, "the element is nil")
+	}
+
+	return value, err
+}
+
+// selectorStep narrows the matches down.
+type selectorStep func(matches []Match) []Match
+
+// predicate checks a single element.
+type predicate func(elem Element) bool
+
+// comparisons are the predicates that compare the element to a value.
+var comparisons = map[string]func(elem Element, value Element) bool{
+	"=":    func(elem Element, value Element) bool { return elem.Equals(value) },
+	"not=": func(elem Element, value Element) bool { return !elem.Equals(value) },
+	"<":    func(elem Element, value Element) bool { return Compare(elem, value) < 0 },
+	"<=":   func(elem Element, value Element) bool { return Compare(elem, value) <= 0 },
+	">":    func(elem Element, value Element) bool { return Compare(elem, value) > 0 },
+	">=":   func(elem Element, value Element) bool { return Compare(elem, value) >= 0 },
+}
+
+// Select returns the elements found by the selector, a vector of steps that are applied in order:
+//
+//   - a key selects the value of the key in each collection, see CollectionElement.Get.
+//   - `*` selects all the values of each collection.
+//   - `**` selects each element and all the elements nested within it.
+//   - a list filters the elements with a predicate: `(= value)`, `(not= value)`, `(< value)`, `(<= value)`,
+//     `(> value)` and `(>= value)` compare the element, or the value of a key within it when given as
+//     `(= key value)`. `(has key)` checks the element contains the key and `(type :db.type/long)` checks its type.
+//
+// For example `[:eva.client.service/tempids *]` selects all the temporary ids of a transaction result and
+// `[* (= :db/ident :book/title)]` selects the maps of a vector that have the ident.
+func Select(elem Element, selector string) (matches []Match, err error) {
+
+	var steps []selectorStep
+	if steps, err = compileSelector(selector); err == nil {
+		matches = []Match{{Element: elem}}
+		for _, step := range steps {
+			matches = step(matches)
+		}
+	}
+
+	return matches, err
+}
+
+// compileSelector parses the selector into the steps.
+func compileSelector(selector string) (steps []selectorStep, err error) {
+
+	var parsed Element
+	if parsed, err = Parse(selector); err != nil {
+		return nil, MakeErrorWithFormat(ErrInvalidSelector, "%s: %s", selector, err)
+	}
+
+	if parsed.ElementType() != VectorType {
+		return nil, MakeErrorWithFormat(ErrInvalidSelector, "%s: expected a vector", selector)
+	}
+
+	for _, part := range parsed.(CollectionElement).Values() {
+		var step selectorStep
+		switch {
+		case part.ElementType() == SymbolType && part.String() == WildcardSelector:
+			step = selectChildren
+		case part.ElementType() == SymbolType && part.String() == DescendantSelector:
+			step = selectDescendants
+		case part.ElementType() == ListType:
+			var pred predicate
+			if pred, err = compilePredicate(part.(CollectionElement)); err == nil {
+				step = filterMatches(pred)
+			}
+		default:
+			step = selectKey(part)
+		}
+
+		if err != nil {
+			return nil, MakeErrorWithFormat(ErrInvalidSelector, "%s: %s", selector, err)
+		}
+
+		steps = append(steps, step)
+	}
+
+	return steps, err
+}
+
+// compilePredicate compiles the predicate list.
+func compilePredicate(list CollectionElement) (pred predicate, err error) {
+
+	args := list.Values()
+	if len(args) == 0 || args[0].ElementType() != SymbolType {
+		return nil, MakeErrorWithFormat(ErrInvalidInput, "predicate %s", list)
+	}
+
+	op, args := args[0].String(), args[1:]
+	compare, isComparison := comparisons[op]
+	switch {
+	case isComparison && len(args) == 1:
+		value := args[0]
+		pred = func(elem Element) bool { return compare(elem, value) }
+	case isComparison && len(args) == 2:
+		key, value := args[0], args[1]
+		pred = func(elem Element) bool {
+			child, e := GetIn(elem, key)
+			return e == nil && compare(child, value)
+		}
+	case op == "has" && len(args) == 1:
+		key := args[0]
+		pred = func(elem Element) bool {
+			coll, is := elem.(CollectionElement)
+			return is && coll.Contains(key)
+		}
+	case op == "type" && len(args) == 1 && args[0].ElementType() == KeywordType:
+		sym := args[0].(SymbolElement)
+		elemType := ElementType(sym.AppendNameOntoNamespace(sym.Name()))
+		pred = func(elem Element) bool { return elem.ElementType() == elemType }
+	default:
+		err = MakeErrorWithFormat(ErrInvalidInput, "predicate %s", list)
+	}
+
+	return pred, err
+}
+
+// selectKey selects the value of the key in each collection.
+func selectKey(key Element) selectorStep {
+	return func(matches []Match) (selected []Match) {
+		for _, match := range matches {
+			if coll, is := match.Element.(CollectionElement); is && coll.Contains(key) {
+				if value, err := coll.Get(key); err == nil {
+					selected = append(selected, match.child(key, value))
+				}
+			}
+		}
+		return selected
+	}
+}
+
+// selectChildren selects all the values of each collection.
+func selectChildren(matches []Match) (selected []Match) {
+	for _, match := range matches {
+		if coll, is := match.Element.(CollectionElement); is {
+			_ = coll.IterateChildren(func(key Element, value Element) error {
+				selected = append(selected, match.child(key, value))
+				return nil
+			})
+		}
+	}
+	return selected
+}
+
+// selectDescendants selects each element and all the elements nested within it, parents before their children.
+func selectDescendants(matches []Match) (selected []Match) {
+	for _, match := range matches {
+		selected = append(selected, match)
+		selected = append(selected, selectDescendants(selectChildren([]Match{match}))...)
+	}
+	return selected
+}
+
+// filterMatches keeps the matches that satisfy the predicate.
+func filterMatches(pred predicate) selectorStep {
+	return func(matches []Match) (selected []Match) {
+		for _, match := range matches {
+			if pred(match.Element) {
+				selected = append(selected, match)
+			}
+		}
+		return selected
+	}
+}
+
+// child creates the match for the value of the key within this match.
+func (match Match) child(key Element, value Element) Match {
+	path := make([]Element, len(match.Path), len(match.Path)+1)
+	copy(path, match.Path)
+	return Match{Path: append(path, key), Element: value}
+}
+
+// unexpectedType creates the error for an element that is not one of the types.
+func unexpectedType(elem Element, types ...ElementType) error {
+	if elem == nil {
+		return MakeErrorWithFormat(ErrUnexpectedType, "nil element, expected %v", types)
+	}
+	return MakeErrorWithFormat(ErrUnexpectedType, "%s, expected %v", elem.ElementType().Name(), types)
+}
+
+// AsInt returns the value of an integer, or a big integer that fits into an int64.
+func AsInt(elem Element) (value int64, err error) {
+	if elem != nil {
+		switch v := elem.Value().(type) {
+		case int64:
+			return v, nil
+		case *big.Int:
+			if v.IsInt64() {
+				return v.Int64(), nil
+			}
+		}
+	}
+	return 0, unexpectedType(elem, IntegerType, BigIntType)
+}
+
+// AsFloat returns the value of a float, double or integer.
+func AsFloat(elem Element) (value float64, err error) {
+	if elem != nil {
+		switch v := elem.Value().(type) {
+		case float64:
+			return v, nil
+		case int64:
+			return float64(v), nil
+		case *Decimal:
+			if f := v.Float64(); !math.IsInf(f, 0) {
+				return f, nil
+			}
+		}
+	}
+	return 0, unexpectedType(elem, FloatType, DoubleType, BigDecType, IntegerType)
+}
+
+// AsBool returns the value of a boolean.
+func AsBool(elem Element) (value bool, err error) {
+	if elem != nil && elem.ElementType() == BooleanType {
+		return elem.Value().(bool), nil
+	}
+	return false, unexpectedType(elem, BooleanType)
+}
+
+// AsString returns the value of a string.
+func AsString(elem Element) (value string, err error) {
+	if elem != nil && elem.ElementType() == StringType {
+		return elem.Value().(string), nil
+	}
+	return "", unexpectedType(elem, StringType)
+}
+
+// AsKeyword returns the keyword.
+func AsKeyword(elem Element) (value SymbolElement, err error) {
+	if elem != nil && elem.ElementType() == KeywordType {
+		if value, is := elem.(SymbolElement); is {
+			return value, nil
+		}
+	}
+	return nil, unexpectedType(elem, KeywordType)
+}
+
+// AsTime returns the value of an instant.
+func AsTime(elem Element) (value time.Time, err error) {
+	if elem != nil && elem.ElementType() == InstantType {
+		return elem.Value().(time.Time), nil
+	}
+	return value, unexpectedType(elem, InstantType)
+}
+
+// AsUUID returns the value of a UUID.
+func AsUUID(elem Element) (value uuid.UUID, err error) {
+	if elem != nil && elem.ElementType() == UUIDType {
+		return elem.Value().(uuid.UUID), nil
+	}
+	return value, unexpectedType(elem, UUIDType)
+}
+
+// AsCollection returns the collection.
+func AsCollection(elem Element) (value CollectionElement, err error) {
+	if value, is := elem.(CollectionElement); is {
+		return value, nil
+	}
+	return nil, unexpectedType(elem, ListType, VectorType, SetType, MapType)
+}
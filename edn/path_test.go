@@ -0,0 +1,212 @@
+// Copyright 2018-2019 Workiva Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package edn
+
+import (
+	"math/big"
+	"time"
+
+	"github.com/Workiva/eva-client-go/test"
+	"github.com/mattrobenolt/gocql/uuid"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Paths in EDN", func() {
+
+	var result Element
+
+	BeforeEach(func() {
+		var err error
+		result, err = Parse(`{:eva.client.service/tempids {#db/id [:db.part/user -1] 100, #db/id [:db.part/user -2] 200}
+                         :db-after {:eva/version 3, :eva/time #inst "2018-01-02T03:04:05.006Z"}
+                         :tx-data [[1 :book/title "Moby Dick" 10 true] [2 :book/title "Emma" 10 true]]
+                         :idents [{:db/ident :book/title :db/valueType :db.type/string}
+                                  {:db/ident :book/year :db/valueType :db.type/long}]}`)
+		Ω(err).Should(BeNil())
+	})
+
+	serialized := func(matches []Match) (out []string) {
+		for _, match := range matches {
+			out = append(out, match.Element.String())
+		}
+		return out
+	}
+
+	Context("GetIn", func() {
+
+		It("should follow the path", func() {
+			elem, err := GetIn(result, ":db-after", ":eva/version")
+			Ω(err).Should(BeNil())
+			Ω(AsInt(elem)).Should(BeEquivalentTo(3))
+
+			elem, err = GetIn(result, ":tx-data", 1, 2)
+			Ω(err).Should(BeNil())
+			Ω(AsString(elem)).Should(Equal("Emma"))
+
+			key, _ := Parse("#db/id [:db.part/user -2]")
+			elem, err = GetIn(result, ":eva.client.service/tempids", key)
+			Ω(err).Should(BeNil())
+			Ω(AsInt(elem)).Should(BeEquivalentTo(200))
+
+			elem, err = GetIn(result)
+			Ω(err).Should(BeNil())
+			Ω(elem).Should(Equal(result))
+		})
+
+		It("should fail if the path is not found", func() {
+			_, err := GetIn(result, ":db-after", ":missing")
+			Ω(err).Should(test.HaveMessage(ErrNoValue))
+
+			_, err = GetIn(result, ":db-after", ":eva/version", 0)
+			Ω(err).Should(test.HaveMessage(ErrNoValue))
+
+			_, err = GetIn(result, ":tx-data", 5)
+			Ω(err).Should(test.HaveMessage(ErrNoValue))
+		})
+
+		It("should fail for nil elements", func() {
+			elem, err := GetIn(nil, "a")
+			Ω(err).Should(test.HaveMessage(ErrNoValue))
+			Ω(elem).Should(BeNil())
+
+			_, err = GetIn(nil)
+			Ω(err).Should(test.HaveMessage(ErrNoValue))
+		})
+	})
+
+	Context("Select", func() {
+
+		It("should select with keys and wildcards", func() {
+			matches, err := Select(result, "[:eva.client.service/tempids *]")
+			Ω(err).Should(BeNil())
+			Ω(serialized(matches)).Should(ConsistOf("100", "200"))
+
+			for _, match := range matches {
+				Ω(match.Path).Should(HaveLen(2))
+				Ω(match.Path[0].String()).Should(Equal(":eva.client.service/tempids"))
+				Ω(match.Path[1].Tag()).Should(Equal("db/id"))
+			}
+
+			matches, err = Select(result, "[:tx-data * 1]")
+			Ω(err).Should(BeNil())
+			Ω(serialized(matches)).Should(Equal([]string{":book/title", ":book/title"}))
+			Ω(matches[1].Path[1].String()).Should(Equal("1"))
+		})
+
+		It("should select with predicates", func() {
+			matches, err := Select(result, "[:idents * (= :db/ident :book/year) :db/valueType]")
+			Ω(err).Should(BeNil())
+			Ω(serialized(matches)).Should(Equal([]string{":db.type/long"}))
+
+			matches, err = Select(result, `[:tx-data * 2 (not= "Emma")]`)
+			Ω(err).Should(BeNil())
+			Ω(serialized(matches)).Should(Equal([]string{`"Moby Dick"`}))
+
+			matches, err = Select(result, "[:eva.client.service/tempids * (> 150)]")
+			Ω(err).Should(BeNil())
+			Ω(serialized(matches)).Should(Equal([]string{"200"}))
+
+			matches, err = Select(result, "[:idents * (has :db/ident) :db/ident]")
+			Ω(err).Should(BeNil())
+			Ω(serialized(matches)).Should(Equal([]string{":book/title", ":book/year"}))
+
+			matches, err = Select(result, "[** (type :db.type/instant)]")
+			Ω(err).Should(BeNil())
+			Ω(serialized(matches)).Should(Equal([]string{`#inst "2018-01-02T03:04:05.006Z"`}))
+			Ω(matches[0].Path).Should(HaveLen(2))
+		})
+
+		It("should select the descendants", func() {
+			elem, _ := Parse("[1 [2 [3]]]")
+			matches, err := Select(elem, "[** (type :db.type/long)]")
+			Ω(err).Should(BeNil())
+			Ω(serialized(matches)).Should(Equal([]string{"1", "2", "3"}))
+
+			matches, err = Select(elem, "[**]")
+			Ω(err).Should(BeNil())
+			Ω(matches).Should(HaveLen(6))
+			Ω(matches[0].Path).Should(BeEmpty())
+		})
+
+		It("should select nothing when there is no match", func() {
+			matches, err := Select(result, "[:missing *]")
+			Ω(err).Should(BeNil())
+			Ω(matches).Should(BeEmpty())
+
+			matches, err = Select(result, "[:db-after :eva/version *]")
+			Ω(err).Should(BeNil())
+			Ω(matches).Should(BeEmpty())
+		})
+
+		It("should not select with invalid selectors", func() {
+			for _, selector := range []string{"[:a", ":a", "[()]", "[(nope 1)]", "[(= 1 2 3)]", "[(type \"long\")]", "[(1)]"} {
+				_, err := Select(result, selector)
+				Ω(err).ShouldNot(BeNil(), selector)
+				Ω(err).Should(test.HaveMessage(ErrInvalidSelector), selector)
+			}
+		})
+	})
+
+	Context("accessors", func() {
+
+		It("should return the typed values", func() {
+			inst := time.Date(2018, 1, 2, 3, 4, 5, 6000000, time.UTC)
+			id, _ := uuid.ParseUUID("f81d4fae-7dec-11d0-a765-00a0c91e6bf6")
+			keyword, _ := NewKeywordElement("book/title")
+
+			Ω(AsInt(NewIntegerElement(5))).Should(BeEquivalentTo(5))
+			Ω(AsInt(bigIntElement(big.NewInt(6)))).Should(BeEquivalentTo(6))
+			Ω(AsFloat(NewDoubleElement(1.5))).Should(BeEquivalentTo(1.5))
+			Ω(AsFloat(NewIntegerElement(2))).Should(BeEquivalentTo(2))
+			Ω(AsFloat(bigDecElement(NewDecimal(big.NewInt(25), 1)))).Should(BeEquivalentTo(2.5))
+			Ω(AsBool(NewBooleanElement(true))).Should(BeTrue())
+			Ω(AsString(NewStringElement("a"))).Should(Equal("a"))
+			Ω(AsKeyword(keyword)).Should(Equal(keyword))
+			Ω(AsTime(NewInstantElement(inst))).Should(Equal(inst))
+			Ω(AsUUID(NewUUIDElement(id))).Should(Equal(id))
+
+			coll, err := AsCollection(result)
+			Ω(err).Should(BeNil())
+			Ω(coll.Len()).Should(Equal(4))
+		})
+
+		It("should fail for the wrong types", func() {
+			str := NewStringElement("a")
+			huge, _ := new(big.Int).SetString("100000000000000000000", 10)
+
+			_, err := AsInt(str)
+			Ω(err).Should(test.HaveMessage(ErrUnexpectedType))
+			_, err = AsInt(bigIntElement(huge))
+			Ω(err).Should(test.HaveMessage(ErrUnexpectedType))
+			_, err = AsInt(nil)
+			Ω(err).Should(test.HaveMessage(ErrUnexpectedType))
+			_, err = AsFloat(str)
+			Ω(err).Should(test.HaveMessage(ErrUnexpectedType))
+			_, err = AsBool(str)
+			Ω(err).Should(test.HaveMessage(ErrUnexpectedType))
+			_, err = AsString(NewIntegerElement(1))
+			Ω(err).Should(test.HaveMessage(ErrUnexpectedType))
+			_, err = AsKeyword(str)
+			Ω(err).Should(test.HaveMessage(ErrUnexpectedType))
+			_, err = AsTime(str)
+			Ω(err).Should(test.HaveMessage(ErrUnexpectedType))
+			_, err = AsUUID(str)
+			Ω(err).Should(test.HaveMessage(ErrUnexpectedType))
+			_, err = AsCollection(str)
+			Ω(err).Should(test.HaveMessage(ErrUnexpectedType))
+		})
+	})
+})
@@ -0,0 +1,311 @@
+// Copyright 2018-2019 Workiva Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package edn
+
+import (
+	"io"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+
+	// PrettyOption is the serializer option to turn on pretty printing, e.g. application/vnd.eva+edn;pretty=true
+	PrettyOption = "pretty"
+
+	// IndentOption is the serializer option for the number of spaces to indent nested elements.
+	IndentOption = "indent"
+
+	// WidthOption is the serializer option for the maximum line width.
+	WidthOption = "width"
+
+	// AlignOption is the serializer option to align the values of a map.
+	AlignOption = "align"
+
+	// SortOption is the serializer option to sort the keys of maps and the items of sets.
+	SortOption = "sort"
+)
+
+// PrettyOptions defines the layout of the pretty printer.
+type PrettyOptions struct {
+
+	// Indent is the number of spaces to indent the children of a collection.
+	Indent int
+
+	// Width is the maximum line width. Collections that fit within the width are printed on one line.
+	Width int
+
+	// AlignKeys will pad the keys of a map so that the values line up.
+	AlignKeys bool
+
+	// SortKeys will sort the keys of maps and the items of sets in the order given by Compare.
+	SortKeys bool
+}
+
+// DefaultPrettyOptions are the options used when none are provided.
+var DefaultPrettyOptions = PrettyOptions{
+	Indent: 2,
+	Width:  80,
+}
+
+// Pretty serializes the element into EDN laid out over multiple lines.
+func Pretty(elem Element, options PrettyOptions) (string, error) {
+	var builder strings.Builder
+	err := newPrettyPrinter(&builder, EvaEdnMimeType, options).print(elem, 0)
+	return builder.String(), err
+}
+
+// prettyOptions reads the pretty printing options from the serializer. The result is false if pretty printing is not
+// turned on.
+func prettyOptions(serializer Serializer) (options PrettyOptions, pretty bool, err error) {
+
+	var value string
+	if value, pretty = serializer.Options(PrettyOption); pretty {
+		if pretty, err = strconv.ParseBool(value); err == nil && pretty {
+			options = DefaultPrettyOptions
+
+			for name, target := range map[string]*int{IndentOption: &options.Indent, WidthOption: &options.Width} {
+				if value, has := serializer.Options(name); has && err == nil {
+					*target, err = strconv.Atoi(value)
+				}
+			}
+
+			for name, target := range map[string]*bool{AlignOption: &options.AlignKeys, SortOption: &options.SortKeys} {
+				if value, has := serializer.Options(name); has && err == nil {
+					*target, err = strconv.ParseBool(value)
+				}
+			}
+		}
+
+		if err != nil {
+			err = MakeErrorWithFormat(ErrInvalidInput, "pretty options in %s: %s", serializer, err)
+		}
+	}
+
+	return options, pretty, err
+}
+
+// prettyPrinter lays out elements over multiple lines.
+type prettyPrinter struct {
+	writer     io.Writer
+	serializer Serializer
+	options    PrettyOptions
+	column     int
+
+	// widths holds the flat widths of the collections that have been measured.
+	widths map[*collectionElemImpl]int
+}
+
+// newPrettyPrinter creates a pretty printer, the serializer is used for the elements that are not collections.
+func newPrettyPrinter(writer io.Writer, serializer Serializer, options PrettyOptions) *prettyPrinter {
+
+	if options.Indent < 0 {
+		options.Indent = 0
+	}
+
+	return &prettyPrinter{
+		writer:     writer,
+		serializer: withoutOption(serializer, PrettyOption),
+		options:    options,
+		widths:     map[*collectionElemImpl]int{},
+	}
+}
+
+// write the string and keep track of the current column.
+func (printer *prettyPrinter) write(str string) (err error) {
+	if _, err = io.WriteString(printer.writer, str); err == nil {
+		if index := strings.LastIndexByte(str, '\n'); index != -1 {
+			printer.column = utf8.RuneCountInString(str[index+1:])
+		} else {
+			printer.column += utf8.RuneCountInString(str)
+		}
+	}
+	return err
+}
+
+// newLine writes a new line indented to the column.
+func (printer *prettyPrinter) newLine(indent int) error {
+	return printer.write("\n" + strings.Repeat(" ", indent))
+}
+
+// entries returns the key/value pairs of the collection, sorted if requested. Keys are nil for lists and vectors.
+func (printer *prettyPrinter) entries(coll *collectionElemImpl) (entries [][2]Element, err error) {
+	if printer.options.SortKeys {
+		entries = coll.sortedEntries()
+	} else {
+		entries, err = coll.entries(printer.serializer)
+	}
+	return entries, err
+}
+
+// prefix returns the tag and start symbol of the collection.
+func prefix(elem Element, startSymbol string) string {
+	if elem.HasTag() {
+		return TagPrefix + elem.Tag() + " " + startSymbol
+	}
+	return startSymbol
+}
+
+// flat serializes the element onto a single line.
+func (printer *prettyPrinter) flat(elem Element) (str string, err error) {
+
+	coll, is := elem.(*collectionElemImpl)
+	if !is {
+		return elem.Serialize(printer.serializer)
+	}
+
+	var entries [][2]Element
+	if entries, err = printer.entries(coll); err == nil {
+		var builder strings.Builder
+		builder.WriteString(prefix(coll, coll.startSymbol))
+
+		for i := 0; i < len(entries) && err == nil; i++ {
+			if i > 0 {
+				builder.WriteString(coll.separatorSymbol)
+			}
+
+			var part string
+			if key := entries[i][0]; key != nil && len(coll.keyValueSeparatorSymbol) > 0 {
+				if part, err = printer.flat(key); err == nil {
+					builder.WriteString(part + coll.keyValueSeparatorSymbol)
+				}
+			}
+
+			if err == nil {
+				if part, err = printer.flat(entries[i][1]); err == nil {
+					builder.WriteString(part)
+				}
+			}
+		}
+
+		builder.WriteString(coll.endSymbol)
+		str = builder.String()
+	}
+
+	return str, err
+}
+
+// width returns the number of runes in the flat form of the element. Collections are measured from the widths of
+// their children and kept, so each element is only measured once however deeply it is nested.
+func (printer *prettyPrinter) width(elem Element) (width int, err error) {
+
+	coll, is := elem.(*collectionElemImpl)
+	if !is {
+		var str string
+		if str, err = elem.Serialize(printer.serializer); err == nil {
+			width = utf8.RuneCountInString(str)
+		}
+		return width, err
+	}
+
+	var has bool
+	if width, has = printer.widths[coll]; has {
+		return width, nil
+	}
+
+	width = utf8.RuneCountInString(prefix(coll, coll.startSymbol)) + utf8.RuneCountInString(coll.endSymbol)
+	if count := coll.Len(); count > 1 {
+		width += (count - 1) * utf8.RuneCountInString(coll.separatorSymbol)
+	}
+
+	err = coll.iterate(func(key Element, value Element) (err error) {
+		var part int
+		if key != nil && len(coll.keyValueSeparatorSymbol) > 0 {
+			if part, err = printer.width(key); err == nil {
+				width += part + utf8.RuneCountInString(coll.keyValueSeparatorSymbol)
+			}
+		}
+
+		if err == nil {
+			if part, err = printer.width(value); err == nil {
+				width += part
+			}
+		}
+		return err
+	})
+
+	if err == nil {
+		printer.widths[coll] = width
+	}
+
+	return width, err
+}
+
+// print writes the element at the current column, breaking collections over multiple lines if they do not fit. The
+// children of a broken collection are indented from the indent column.
+func (printer *prettyPrinter) print(elem Element, indent int) (err error) {
+
+	coll, is := elem.(*collectionElemImpl)
+	fits := !is || coll.Len() == 0
+	if !fits {
+		var width int
+		if width, err = printer.width(coll); err != nil {
+			return err
+		}
+		fits = printer.column+width <= printer.options.Width
+	}
+
+	if fits {
+		var flat string
+		if flat, err = printer.flat(elem); err == nil {
+			err = printer.write(flat)
+		}
+		return err
+	}
+
+	childIndent := indent + printer.options.Indent
+	isMap := len(coll.keyValueSeparatorSymbol) > 0
+
+	var entries [][2]Element
+	var keys []string
+	keyWidth := 0
+	if entries, err = printer.entries(coll); err == nil && isMap {
+		keys = make([]string, len(entries))
+		for i := 0; i < len(entries) && err == nil; i++ {
+			if keys[i], err = printer.flat(entries[i][0]); err == nil {
+				if width := utf8.RuneCountInString(keys[i]); width > keyWidth {
+					keyWidth = width
+				}
+			}
+		}
+	}
+
+	if err == nil {
+		err = printer.write(prefix(coll, coll.startSymbol))
+	}
+
+	for i := 0; i < len(entries) && err == nil; i++ {
+		if err = printer.newLine(childIndent); err == nil && isMap {
+			padding := 1
+			if printer.options.AlignKeys {
+				padding += keyWidth - utf8.RuneCountInString(keys[i])
+			}
+			err = printer.write(keys[i] + strings.Repeat(" ", padding))
+		}
+
+		if err == nil {
+			err = printer.print(entries[i][1], childIndent)
+		}
+	}
+
+	if err == nil {
+		if err = printer.newLine(indent); err == nil {
+			err = printer.write(coll.endSymbol)
+		}
+	}
+
+	return err
+}
@@ -0,0 +1,134 @@
+// Copyright 2018-2019 Workiva Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package edn
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/Workiva/eva-client-go/test"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Pretty printing EDN", func() {
+
+	const data = `[{:db/id #db/id [:db.part/user] :book/title "Dune" :book/year 1965}
+                   {:db/id #db/id [:db.part/user] :book/title "Neuromancer" :book/tags #{"cyberpunk" "classic"}}]`
+
+	parse := func(data string) Element {
+		elem, err := Parse(data)
+		Ω(err).Should(BeNil())
+		return elem
+	}
+
+	It("should keep small collections on one line", func() {
+		str, err := Pretty(parse(`{:a [1 2 3]}`), DefaultPrettyOptions)
+		Ω(err).Should(BeNil())
+		Ω(str).Should(BeEquivalentTo(`{:a [1 2 3]}`))
+
+		str, err = Pretty(parse(`42`), DefaultPrettyOptions)
+		Ω(err).Should(BeNil())
+		Ω(str).Should(BeEquivalentTo(`42`))
+	})
+
+	It("should break collections that do not fit and sort the keys", func() {
+		str, err := Pretty(parse(data), PrettyOptions{Indent: 2, Width: 40, SortKeys: true})
+		Ω(err).Should(BeNil())
+		Ω(str).Should(BeEquivalentTo(`[
+  {
+    :book/title "Dune"
+    :book/year 1965
+    :db/id #db/id [:db.part/user]
+  }
+  {
+    :book/tags #{"classic" "cyberpunk"}
+    :book/title "Neuromancer"
+    :db/id #db/id [:db.part/user]
+  }
+]`))
+	})
+
+	It("should measure collections the same as their flat form", func() {
+		for _, data := range []string{data, `()`, `[1]`, `{}`, `#{"é" \a}`, `#my/tag {:a (1 2) "b" [[] {}]}`} {
+			elem := parse(data)
+			printer := newPrettyPrinter(nil, EvaEdnMimeType, DefaultPrettyOptions)
+			flat, err := printer.flat(elem)
+			Ω(err).Should(BeNil())
+			width, err := printer.width(elem)
+			Ω(err).Should(BeNil())
+			Ω(width).Should(BeEquivalentTo(utf8.RuneCountInString(flat)), data)
+		}
+	})
+
+	It("should measure each collection once", func() {
+		var builder strings.Builder
+		printer := newPrettyPrinter(&builder, EvaEdnMimeType, PrettyOptions{Width: 10})
+		Ω(printer.print(parse(strings.Repeat("[", 200)+strings.Repeat("]", 200)), 0)).Should(BeNil())
+		Ω(printer.widths).Should(HaveLen(200))
+		Ω(builder.String()).Should(ContainSubstring("\n[[[[[]]]]]\n]"))
+	})
+
+	It("should align the map keys", func() {
+		str, err := Pretty(parse(data), PrettyOptions{Indent: 4, Width: 80, SortKeys: true, AlignKeys: true})
+		Ω(err).Should(BeNil())
+		Ω(str).Should(BeEquivalentTo(`[
+    {:book/title "Dune", :book/year 1965, :db/id #db/id [:db.part/user]}
+    {
+        :book/tags  #{"classic" "cyberpunk"}
+        :book/title "Neuromancer"
+        :db/id      #db/id [:db.part/user]
+    }
+]`))
+	})
+
+	It("should nest broken collections from the entry indent", func() {
+		str, err := Pretty(parse(`#my/tag {:key [100 200 300]}`), PrettyOptions{Indent: 1, Width: 10})
+		Ω(err).Should(BeNil())
+		Ω(str).Should(BeEquivalentTo(`#my/tag {
+ :key [
+  100
+  200
+  300
+ ]
+}`))
+	})
+
+	It("should be available through the serializer options", func() {
+		serializer, err := GetSerializer(string(EvaEdnMimeType) + ";pretty=true,width=20,indent=1,sort=true,align=false")
+		Ω(err).Should(BeNil())
+
+		str, err := parse(`(:b "bbbbbbbbbb" :a "aaaaaaaaaa")`).Serialize(serializer)
+		Ω(err).Should(BeNil())
+		Ω(str).Should(BeEquivalentTo(`(
+ :b
+ "bbbbbbbbbb"
+ :a
+ "aaaaaaaaaa"
+)`))
+
+		str, err = parse(`[1 2]`).Serialize(SerializerMimeType(string(EvaEdnMimeType) + ";pretty=false"))
+		Ω(err).Should(BeNil())
+		Ω(str).Should(BeEquivalentTo(`[1 2]`))
+	})
+
+	It("should report invalid options", func() {
+		_, err := parse(`[1 2]`).Serialize(SerializerMimeType(string(EvaEdnMimeType) + ";pretty=true,width=wide"))
+		Ω(err).Should(test.HaveMessage(ErrInvalidInput))
+
+		_, err = parse(`[1 2]`).Serialize(SerializerMimeType(string(EvaEdnMimeType) + ";pretty=maybe"))
+		Ω(err).Should(test.HaveMessage(ErrInvalidInput))
+	})
+})
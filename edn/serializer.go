@@ -28,6 +28,12 @@ var serializerFactories = map[SerializerMimeType]func(string) (Serializer, error
 	EvaEdnMimeType.MimeType(): func(s string) (Serializer, error) {
 		return SerializerMimeType(s), nil
 	},
+	JSONMimeType.MimeType(): func(s string) (Serializer, error) {
+		return SerializerMimeType(s), nil
+	},
+	TransitMimeType.MimeType(): func(s string) (Serializer, error) {
+		return SerializerMimeType(s), nil
+	},
 }
 
 // Serializer defines the interface for converting the entity into a serialized edn value.
@@ -82,3 +88,26 @@ func GetSerializerByType(serializerType SerializerMimeType) (serializer Serializ
 
 	return serializer, err
 }
+
+// withoutOption returns the serializer with the named option removed, this is used when a serializer hands off
+// elements to the plain version of itself.
+func withoutOption(serializer Serializer, name string) Serializer {
+
+	if mimeType, is := serializer.(SerializerMimeType); is {
+		var options []string
+		base, _ := scrapeOptionFromMime(mimeType, func(option string, value string) bool {
+			if option != name {
+				options = append(options, option+"="+value)
+			}
+			return true
+		})
+
+		if len(options) > 0 {
+			base += SerializerMimeType(";" + strings.Join(options, ","))
+		}
+
+		serializer = base
+	}
+
+	return serializer
+}
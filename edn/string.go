@@ -15,7 +15,12 @@
 package edn
 
 import (
+	"fmt"
 	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf16"
+	"unicode/utf8"
 )
 
 type stringProcessor func(string) (Element, error)
@@ -23,6 +28,34 @@ type stringProcessor func(string) (Element, error)
 var stringProcessors = map[string]stringProcessor{
 	UUIDElementTag:    uuidStringProcessor,
 	InstantElementTag: instStringProcessor,
+	URIElementTag:     uriStringProcessor,
+	BytesElementTag:   bytesStringProcessor,
+}
+
+// stringPattern is the lexer pattern for strings, any character other than the quote and the backslash is allowed.
+const stringPattern = `"([^"\\]|\\([tbnrf"'\\]|u[0-9A-Fa-f][0-9A-Fa-f][0-9A-Fa-f][0-9A-Fa-f]))*"`
+
+// stringTokenMatcher is a hand-written equivalent of stringPattern.
+func stringTokenMatcher(token string) bool {
+	if len(token) < 2 || token[0] != '"' || token[len(token)-1] != '"' {
+		return false
+	}
+
+	for i := 1; i < len(token)-1; i++ {
+		switch token[i] {
+		case '\\':
+			if i+1 < len(token)-1 && strings.IndexByte("tbnrf\"'\\", token[i+1]) != -1 {
+				i++
+			} else if i+5 < len(token)-1 && token[i+1] == 'u' && isHex(token[i+2:i+6]) {
+				i += 5
+			} else {
+				return false
+			}
+		case '"':
+			return false
+		}
+	}
+	return true
 }
 
 var specialStrings = map[rune]rune{
@@ -38,44 +71,112 @@ var specialStrings = map[rune]rune{
 
 // normalStringProcessor defines the rule for normal string processing.
 func normalStringProcessor(tokenValue string) (el Element, e error) {
+
+	// most strings have no escapes and are used as is.
+	if strings.IndexByte(tokenValue, '\\') == -1 {
+		return NewStringElement(tokenValue), nil
+	}
+
 	length := len(tokenValue)
 
-	var out []rune
+	var out strings.Builder
+	out.Grow(length)
 	for i := 0; length > i && e == nil; i++ {
-		if current := rune(tokenValue[i]); current == '\\' {
+		if current := tokenValue[i]; current == '\\' {
 			if next := i + 1; length > next {
 				nextCh := rune(tokenValue[next])
 				if ch, has := specialStrings[nextCh]; has {
 					i++
-					out = append(out, ch)
-				} else if nextCh == 'u' && length > next+4 {
-					i++ // remove the 'u'
-
-					// Look for the next 4 characters
-					unicode := tokenValue[next+1 : next+5]
-					var v int64
-					if v, e = strconv.ParseInt(unicode, 16, 16); e == nil {
-						i = i + 4
-						out = append(out, rune(v))
+					out.WriteRune(ch)
+				} else if nextCh == 'u' {
+					var r rune
+					if r, e = unicodeEscape(tokenValue[next+1:]); e == nil {
+						i += 5
+
+						// characters outside of the basic plane are escaped as a surrogate pair, a surrogate on its own
+						// is written as the replacement character.
+						if utf16.IsSurrogate(r) && strings.HasPrefix(tokenValue[i+1:], "\\u") {
+							if low, lowErr := unicodeEscape(tokenValue[i+3:]); lowErr == nil {
+								if pair := utf16.DecodeRune(r, low); pair != unicode.ReplacementChar {
+									r = pair
+									i += 6
+								}
+							}
+						}
+						out.WriteRune(r)
 					}
 				} else {
-					e = MakeErrorWithFormat(ErrParserError, "Invalid escape character: %#U", ch)
+					e = MakeErrorWithFormat(ErrParserError, "Invalid escape character: %#U", nextCh)
 				}
 			} else {
 				e = MakeError(ErrParserError, "Escape character found at end of string.")
 			}
 		} else {
-			out = append(out, current)
+			out.WriteByte(current)
 		}
 	}
 
 	if e == nil {
-		el = NewStringElement(string(out))
+		el = NewStringElement(out.String())
 	}
 
 	return el, e
 }
 
+// unicodeEscape reads the 4 hexadecimal digits of a \u escape at the start of the value.
+func unicodeEscape(value string) (r rune, err error) {
+	if len(value) < 4 || !isHex(value[:4]) {
+		return 0, MakeError(ErrParserError, "Invalid unicode escape, expected 4 hexadecimal digits.")
+	}
+
+	v, _ := strconv.ParseUint(value[:4], 16, 32)
+	return rune(v), nil
+}
+
+// quoteString quotes the string for EDN. Printable characters are written as is, the other characters are escaped
+// so that the string reads back the same.
+func quoteString(value string) string {
+
+	var out strings.Builder
+	out.Grow(len(value) + 2)
+	out.WriteByte('"')
+
+	for i, r := range value {
+		switch r {
+		case '"':
+			out.WriteString(`\"`)
+		case '\\':
+			out.WriteString(`\\`)
+		case '\n':
+			out.WriteString(`\n`)
+		case '\t':
+			out.WriteString(`\t`)
+		case '\r':
+			out.WriteString(`\r`)
+		case '\b':
+			out.WriteString(`\b`)
+		case '\f':
+			out.WriteString(`\f`)
+		default:
+			switch {
+			case r == utf8.RuneError && !strings.HasPrefix(value[i:], string(utf8.RuneError)):
+				// invalid utf-8 cannot be written, use the replacement character.
+				out.WriteString(`\ufffd`)
+			case unicode.IsPrint(r):
+				out.WriteRune(r)
+			case r > 0xFFFF:
+				high, low := utf16.EncodeRune(r)
+				fmt.Fprintf(&out, `\u%04x\u%04x`, high, low)
+			default:
+				fmt.Fprintf(&out, `\u%04x`, r)
+			}
+		}
+	}
+
+	out.WriteByte('"')
+	return out.String()
+}
+
 // init will add the element factory to the collection of factories
 func initString(lexer Lexer) (err error) {
 	if err = addElementTypeFactory(StringType, func(input interface{}) (elem Element, e error) {
@@ -86,7 +187,7 @@ func initString(lexer Lexer) (err error) {
 		}
 		return elem, e
 	}); err == nil {
-		lexer.AddPattern(StringPrimitive, "\"(\\w|\\d| |[-+*!?$%&=<>.#:()\\[\\]@^;,/{}'|`~]|\\\\([tbnrf\"'\\\\]|u[0-9A-Fa-f][0-9A-Fa-f][0-9A-Fa-f][0-9A-Fa-f]))*\"", func(tag string, tokenValue string) (el Element, e error) {
+		lexer.AddPattern(StringPrimitive, stringPattern, func(tag string, tokenValue string) (el Element, e error) {
 			var proc stringProcessor
 			var has bool
 
@@ -117,7 +218,11 @@ func NewStringElement(value string) (elem Element) {
 			if len(tag) > 0 {
 				out = TagPrefix + tag + " "
 			}
-			out += strconv.Quote(value.(string))
+			out += quoteString(value.(string))
+		case JSONMimeType:
+			out = jsonTagged(tag, jsonQuote(value.(string)))
+		case TransitMimeType:
+			out, e = transitString(tag, value)
 		default:
 			e = MakeError(ErrUnknownMimeType, serializer.MimeType())
 		}
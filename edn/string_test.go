@@ -105,6 +105,33 @@ var _ = Describe("String in EDN", func() {
 			Ω(edn).Should(BeEquivalentTo("\"" + testValue + "\""))
 		})
 
+		It("should serialize multilingual strings so they read back", func() {
+			values := []string{
+				"日本語",
+				"Grüße, 😀!",
+				"tab\tnewline\nreturn\rquote\"slash\\",
+				"bell\u0007 delete\u007f",
+				"\u2028\U000e0001",
+			}
+
+			for _, v := range values {
+				edn, err := NewStringElement(v).Serialize(EvaEdnMimeType)
+				Ω(err).Should(BeNil())
+
+				elem, err := Parse(edn)
+				Ω(err).Should(BeNil())
+				Ω(elem.Value()).Should(BeEquivalentTo(v))
+			}
+		})
+
+		It("should escape characters that are not printable", func() {
+			Ω(quoteString("a\u0007b")).Should(BeEquivalentTo(`"a\u0007b"`))
+			Ω(quoteString("\b\f")).Should(BeEquivalentTo(`"\b\f"`))
+			Ω(quoteString("\U000e0001")).Should(BeEquivalentTo(`"\udb40\udc01"`))
+			Ω(quoteString("\xff")).Should(BeEquivalentTo(`"\ufffd"`))
+			Ω(quoteString("é😀")).Should(BeEquivalentTo(`"é😀"`))
+		})
+
 		It("should serialize the string without an issue", func() {
 			elem := NewStringElement(testValue)
 			Ω(elem).ShouldNot(BeNil())
@@ -145,6 +172,12 @@ var _ = Describe("String in EDN", func() {
 			&testDefinition{"\"\\\\t\"", "\\t"},
 			&testDefinition{"\"\\u2318\"", "⌘"},
 			&testDefinition{"\"\\u20AC\"", "€"},
+			&testDefinition{"\"\\u00e9t\\u00E9\"", "été"},
+			&testDefinition{"\"\\ud83d\\ude00\"", "😀"},
+			&testDefinition{"\"\\ud83d\"", "\ufffd"},
+			&testDefinition{"\"\\ude00 value\"", "\ufffd value"},
+			&testDefinition{"\"日本語\"", "日本語"},
+			&testDefinition{"\"Grüße, 😀!\"", "Grüße, 😀!"},
 			&testDefinition{"\"value value\"", "value value"},
 			&testDefinition{"\"()\"", "()"},
 			&testDefinition{"\"[]\"", "[]"},
@@ -15,8 +15,6 @@
 package edn
 
 import (
-	"fmt"
-	"regexp"
 	"strings"
 )
 
@@ -46,12 +44,12 @@ const (
 	// Symbols begin with a non-numeric character and can contain alphanumeric characters and . * + ! - _ ? $ % & = < >.
 	// If -, + or . are the first character, the second character (if any) must be non-numeric. Additionally, : # are
 	// allowed as constituent characters in symbols other than as the first character.
-	symbolRegex = `^((` + numericModifierSymbols + `)|((((` + numericModifierSymbols + `)(` + legalFirstSymbols + `|[[:alpha:]]))|(` + legalFirstSymbols + `|[[:alpha:]]))+(` + numericModifierSymbols + `|` + legalFirstSymbols + `|` + specialSymbols + `|[[:alnum:]])*))$`
+	symbolRegex = `^((` + numericModifierSymbols + `)|((((` + numericModifierSymbols + `)(` + numericModifierSymbols + `|` + legalFirstSymbols + `|[[:alpha:]]))|(` + legalFirstSymbols + `|[[:alpha:]]))+(` + numericModifierSymbols + `|` + legalFirstSymbols + `|` + specialSymbols + `|[[:alnum:]])*))$`
 )
 
 // init will add the element factory to the collection of factories
 func initSymbol(lexer Lexer) (err error) {
-	lexer.AddPattern(SymbolPrimitive, "[*!?$%&=<>_a-zA-Z.]([-+*!?$%&=<>_.#]|\\w)*(/([-+*!?$%&=<>_.#]|\\w)*)?", func(tag string, tokenValue string) (el Element, e error) {
+	lexer.AddPattern(SymbolPrimitive, symbolPattern, func(tag string, tokenValue string) (el Element, e error) {
 		if el, e = NewSymbolElement(tokenValue); e == nil {
 			e = el.SetTag(tag)
 		}
@@ -61,8 +59,64 @@ func initSymbol(lexer Lexer) (err error) {
 	return err
 }
 
-// symbolMatcher is the matching mechanism for symbols
-var symbolMatcher = regexp.MustCompile(symbolRegex).MatchString
+// symbolMatcher is the matching mechanism for symbols, it is a hand-written equivalent of symbolRegex.
+func symbolMatcher(value string) bool {
+
+	// +, - and . are symbols by themselves, otherwise they must be followed by a non-numeric character, e.g. ...
+	start := 0
+	if len(value) > 0 && isNumericModifier(value[0]) {
+		if len(value) == 1 {
+			return true
+		}
+		start = 1
+	}
+
+	if start >= len(value) || !(isLegalFirstSymbol(value[start]) || (start > 0 && isNumericModifier(value[start]))) {
+		return false
+	}
+
+	for i := start + 1; i < len(value); i++ {
+		if ch := value[i]; !isNumericModifier(ch) && !isLegalFirstSymbol(ch) && !isDigit(ch) && ch != ':' && ch != '#' {
+			return false
+		}
+	}
+	return true
+}
+
+// isNumericModifier checks if the byte is one of the symbols that can modify a numeric.
+func isNumericModifier(ch byte) bool {
+	return ch == '.' || ch == '+' || ch == '-'
+}
+
+// isLegalFirstSymbol checks if the byte is a letter or one of the other legal first symbols.
+func isLegalFirstSymbol(ch byte) bool {
+	return isAlpha(ch) || strings.IndexByte("*!_?$%&=<>", ch) != -1
+}
+
+// symbolPattern is the lexer pattern for symbols.
+const symbolPattern = "[*!?$%&=<>_a-zA-Z.]([-+*!?$%&=<>_.#]|\\w)*(/([-+*!?$%&=<>_.#]|\\w)*)?"
+
+// symbolTokenMatcher is a hand-written equivalent of symbolPattern.
+func symbolTokenMatcher(token string) bool {
+	return len(token) > 0 && (isAlpha(token[0]) || strings.IndexByte("*!?$%&=<>_.", token[0]) != -1) &&
+		namespacedTokenMatcher(token[1:], "-+*!?$%&=<>_.#")
+}
+
+// namespacedTokenMatcher checks that the rest of a symbol or keyword token is made of word characters and the others
+// and holds at most one separator.
+func namespacedTokenMatcher(rest string, others string) bool {
+	separated := false
+	for i := 0; i < len(rest); i++ {
+		switch ch := rest[i]; {
+		case isWord(ch), strings.IndexByte(others, ch) != -1:
+		case ch == SymbolSeparator[0] && !separated:
+			separated = true
+		default:
+			return false
+		}
+	}
+	return true
+}
 
 // IsValidNamespace checks if the namespace is valid.
 func IsValidNamespace(namespace string) bool {
@@ -96,7 +150,7 @@ type symbolElemImpl struct {
 
 func encodeSymbol(prefix string, name string) string {
 	if len(prefix) > 0 {
-		name = fmt.Sprintf("%s%s%s", prefix, SymbolSeparator, name)
+		name = prefix + SymbolSeparator + name
 	}
 	return name
 }
@@ -166,6 +220,15 @@ func NewSymbolElement(parts ...string) (elem SymbolElement, err error) {
 				if elem, ok := value.(SymbolElement); ok {
 					out += elem.AppendNameOntoNamespace(elem.Name())
 				}
+			case JSONMimeType:
+				if elem, ok := value.(SymbolElement); ok {
+					if out = jsonQuote(elem.AppendNameOntoNamespace(elem.Name())); elem.ElementType() == SymbolType {
+						out = jsonTagged(jsonTagSymbol, out)
+					}
+					out = jsonTagged(tag, out)
+				}
+			case TransitMimeType:
+				out, err = transitString(tag, value)
 			default:
 				err = MakeError(ErrUnknownMimeType, serializer.MimeType())
 			}
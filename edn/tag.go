@@ -0,0 +1,164 @@
+// Copyright 2018-2019 Workiva Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package edn
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+)
+
+const (
+
+	// ErrInvalidTag defines the error for tags that cannot be registered.
+	ErrInvalidTag = ErrorMessage("Invalid tag")
+)
+
+// TagReader converts a tagged element read by the parser into the element that is returned in its place. The element
+// passed in still carries the tag.
+type TagReader func(Element) (Element, error)
+
+// TagWriter converts a go value into the element that is written after the tag.
+type TagWriter func(interface{}) (Element, error)
+
+// tagWriter holds the registered writer for a go type.
+type tagWriter struct {
+	tag    string
+	writer TagWriter
+}
+
+// tagRegistry holds the registered tag readers and writers.
+type tagRegistry struct {
+	lock          sync.RWMutex
+	readers       map[string]TagReader
+	writers       map[reflect.Type]*tagWriter
+	defaultReader TagReader
+}
+
+var registeredTags = &tagRegistry{
+	readers: map[string]TagReader{},
+	writers: map[reflect.Type]*tagWriter{},
+}
+
+// cleanTag validates the tag, the builtin tags such as #inst and #uuid cannot be replaced.
+func cleanTag(tag string) (_ string, err error) {
+
+	tag = strings.TrimPrefix(tag, TagPrefix)
+
+	var prefix, name string
+	if prefix, name, err = decodeSymbol(tag); err == nil {
+		tag = encodeSymbol(prefix, name)
+		if _, has := stringProcessors[tag]; has {
+			err = MakeErrorWithFormat(ErrInvalidTag, "#%s is a builtin tag", tag)
+		}
+	} else {
+		err = MakeError(ErrInvalidTag, err)
+	}
+
+	return tag, err
+}
+
+// RegisterTagReader registers the reader for the tag, replacing any reader already registered. Passing a nil reader
+// removes the registration.
+func RegisterTagReader(tag string, reader TagReader) (err error) {
+	if tag, err = cleanTag(tag); err == nil {
+		registeredTags.lock.Lock()
+		defer registeredTags.lock.Unlock()
+
+		if reader != nil {
+			registeredTags.readers[tag] = reader
+		} else {
+			delete(registeredTags.readers, tag)
+		}
+	}
+	return err
+}
+
+// SetDefaultTagReader sets the reader used for the tags that have no reader registered. Passing nil restores the
+// default behaviour of keeping the tag on the element.
+func SetDefaultTagReader(reader TagReader) {
+	registeredTags.lock.Lock()
+	defer registeredTags.lock.Unlock()
+
+	registeredTags.defaultReader = reader
+}
+
+// RegisterTagWriter registers the writer for the type of the sample value. Values of that type are converted by the
+// writer and tagged when they are marshalled or turned into primitive elements. Passing a nil writer removes the
+// registration.
+func RegisterTagWriter(tag string, sample interface{}, writer TagWriter) (err error) {
+	if sample == nil {
+		return MakeError(ErrInvalidInput, "sample value is nil")
+	}
+
+	if tag, err = cleanTag(tag); err == nil {
+		registeredTags.lock.Lock()
+		defer registeredTags.lock.Unlock()
+
+		if sampleType := reflect.TypeOf(sample); writer != nil {
+			registeredTags.writers[sampleType] = &tagWriter{tag: tag, writer: writer}
+		} else {
+			delete(registeredTags.writers, sampleType)
+		}
+	}
+	return err
+}
+
+// readTagged runs the registered reader for the tag of the element. Elements without tags, with builtin tags or without
+// a reader are returned as is.
+func readTagged(elem Element) (Element, error) {
+
+	if elem == nil || !elem.HasTag() {
+		return elem, nil
+	}
+
+	registeredTags.lock.RLock()
+	reader, has := registeredTags.readers[elem.Tag()]
+	if !has {
+		if _, builtin := stringProcessors[elem.Tag()]; !builtin {
+			reader = registeredTags.defaultReader
+		}
+	}
+	registeredTags.lock.RUnlock()
+
+	if reader != nil {
+		return reader(elem)
+	}
+	return elem, nil
+}
+
+// writeTagged runs the registered writer for the type of the value. The result is false if there is no writer.
+func writeTagged(value interface{}) (elem Element, has bool, err error) {
+
+	if value == nil {
+		return nil, false, nil
+	}
+
+	registeredTags.lock.RLock()
+	writer, has := registeredTags.writers[reflect.TypeOf(value)]
+	registeredTags.lock.RUnlock()
+
+	if has {
+		if elem, err = writer.writer(value); err == nil {
+			if elem == nil {
+				err = MakeErrorWithFormat(ErrInvalidElement, "tag writer for #%s returned nil", writer.tag)
+			} else {
+				err = elem.SetTag(writer.tag)
+			}
+		}
+	}
+
+	return elem, has, err
+}
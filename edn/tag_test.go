@@ -0,0 +1,172 @@
+// Copyright 2018-2019 Workiva Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package edn
+
+import (
+	"errors"
+
+	"github.com/Workiva/eva-client-go/test"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type money struct {
+	Amount   string
+	Currency string
+}
+
+var _ = Describe("Tag registry", func() {
+
+	// moneyReader converts #money ["1.50" "USD"] into #money {:amount 1.50M :currency "USD"}
+	moneyReader := func(elem Element) (Element, error) {
+		if elem.ElementType() == MapType {
+			return elem, nil
+		}
+
+		vector, is := elem.(CollectionElement)
+		if !is || vector.Len() != 2 {
+			return nil, errors.New("expected [amount currency]")
+		}
+
+		amount, _ := vector.Get(0)
+		currency, _ := vector.Get(1)
+
+		dec, err := ParseDecimal(amount.Value().(string))
+		if err != nil {
+			return nil, err
+		}
+
+		amountKey, _ := NewKeywordElement("amount")
+		currencyKey, _ := NewKeywordElement("currency")
+
+		amountElem, err := NewBigDecElement(dec)
+		if err != nil {
+			return nil, err
+		}
+
+		m, err := NewMap(&pairImpl{amountKey, amountElem}, &pairImpl{currencyKey, currency})
+		if err == nil {
+			err = m.SetTag(elem.Tag())
+		}
+		return m, err
+	}
+
+	AfterEach(func() {
+		Ω(RegisterTagReader("money", nil)).Should(BeNil())
+		Ω(RegisterTagWriter("money", money{}, nil)).Should(BeNil())
+		SetDefaultTagReader(nil)
+	})
+
+	Context("readers", func() {
+
+		It("should convert the tagged elements", func() {
+			Ω(RegisterTagReader("#money", moneyReader)).Should(BeNil())
+
+			elem, err := Parse(`[#money ["1.50" "USD"] #other 1]`)
+			Ω(err).Should(BeNil())
+
+			expected, err := Parse(`[#money {:amount 1.50M :currency "USD"} #other 1]`)
+			Ω(err).Should(BeNil())
+			Ω(elem.Equals(expected)).Should(BeTrue())
+		})
+
+		It("should read tags from json and transit", func() {
+			Ω(RegisterTagReader("money", moneyReader)).Should(BeNil())
+
+			elem, err := ParseJSON(`{"#money": ["1.50", "USD"]}`)
+			Ω(err).Should(BeNil())
+			Ω(elem.ElementType()).Should(BeEquivalentTo(MapType))
+
+			elem, err = ParseTransit(`["~#money", ["1.50", "USD"]]`)
+			Ω(err).Should(BeNil())
+			Ω(elem.ElementType()).Should(BeEquivalentTo(MapType))
+		})
+
+		It("should report the reader errors", func() {
+			Ω(RegisterTagReader("money", moneyReader)).Should(BeNil())
+
+			_, err := Parse(`#money "1.50"`)
+			Ω(err).ShouldNot(BeNil())
+		})
+
+		It("should use the default reader for unknown tags", func() {
+			SetDefaultTagReader(func(elem Element) (Element, error) {
+				return elem, elem.SetTag("")
+			})
+
+			elem, err := Parse(`[#unknown 1 #inst "1985-04-12T23:20:50Z"]`)
+			Ω(err).Should(BeNil())
+
+			first, _ := elem.(CollectionElement).Get(0)
+			Ω(first.HasTag()).Should(BeFalse())
+
+			second, _ := elem.(CollectionElement).Get(1)
+			Ω(second.ElementType()).Should(BeEquivalentTo(InstantType))
+		})
+
+		It("should not replace the builtin tags", func() {
+			Ω(RegisterTagReader("inst", moneyReader)).Should(test.HaveMessage(ErrInvalidTag))
+			Ω(RegisterTagReader("#uuid", moneyReader)).Should(test.HaveMessage(ErrInvalidTag))
+			Ω(RegisterTagReader("", moneyReader)).Should(test.HaveMessage(ErrInvalidTag))
+		})
+	})
+
+	Context("writers", func() {
+
+		moneyWriter := func(value interface{}) (Element, error) {
+			m := value.(money)
+			return NewVector(NewStringElement(m.Amount), NewStringElement(m.Currency))
+		}
+
+		It("should tag the marshalled values", func() {
+			Ω(RegisterTagWriter("money", money{}, moneyWriter)).Should(BeNil())
+
+			data, err := Marshal(struct {
+				Price money `edn:"price"`
+			}{money{"1.50", "USD"}})
+			Ω(err).Should(BeNil())
+			Ω(string(data)).Should(BeEquivalentTo(`{:price #money ["1.50" "USD"]}`))
+		})
+
+		It("should tag primitive elements", func() {
+			Ω(RegisterTagWriter("money", money{}, moneyWriter)).Should(BeNil())
+
+			elem, err := NewPrimitiveElement(money{"2", "EUR"})
+			Ω(err).Should(BeNil())
+			Ω(elem.Tag()).Should(BeEquivalentTo("money"))
+		})
+
+		It("should round trip through the reader", func() {
+			Ω(RegisterTagWriter("money", money{}, moneyWriter)).Should(BeNil())
+			Ω(RegisterTagReader("money", moneyReader)).Should(BeNil())
+
+			data, err := Marshal(money{"1.50", "USD"})
+			Ω(err).Should(BeNil())
+
+			elem, err := Parse(string(data))
+			Ω(err).Should(BeNil())
+			Ω(elem.ElementType()).Should(BeEquivalentTo(MapType))
+		})
+
+		It("should not register bad writers", func() {
+			Ω(RegisterTagWriter("money", nil, moneyWriter)).Should(test.HaveMessage(ErrInvalidInput))
+			Ω(RegisterTagWriter("inst", money{}, moneyWriter)).Should(test.HaveMessage(ErrInvalidTag))
+
+			Ω(RegisterTagWriter("money", money{}, func(interface{}) (Element, error) { return nil, nil })).Should(BeNil())
+			_, err := NewPrimitiveElement(money{})
+			Ω(err).Should(test.HaveMessage(ErrInvalidElement))
+		})
+	})
+})
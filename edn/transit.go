@@ -0,0 +1,678 @@
+// Copyright 2018-2019 Workiva Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package edn
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"math"
+	"math/big"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mattrobenolt/gocql/uuid"
+)
+
+// Transit is written in the non verbose transit+json form (https://github.com/cognitect/transit-format): maps are
+// written as arrays starting with "^ " and repeated keywords, symbols, tags and map keys are replaced by cache codes.
+
+const (
+
+	// transitEscape prefixes the encoded scalar values.
+	transitEscape = "~"
+
+	// transitTag prefixes the tag of a tagged value.
+	transitTag = "~#"
+
+	// transitCacheRef prefixes the cache codes.
+	transitCacheRef = "^"
+
+	// transitMapAsArray marks an array as a map.
+	transitMapAsArray = "^ "
+
+	// transitQuote is the tag used to wrap scalar values at the top level.
+	transitQuote = "'"
+
+	// transitCacheBase is the number of characters used for each digit of the cache codes.
+	transitCacheBase = 44
+
+	// transitCacheSize is the number of entries in the cache before it is reset.
+	transitCacheSize = transitCacheBase * transitCacheBase
+
+	// transitMaxInt is the largest integer written as a number, larger integers lose precision in JavaScript.
+	transitMaxInt = 1<<53 - 1
+)
+
+// transitCache keeps track of the cacheable strings of a single transit document.
+type transitCache struct {
+	codes  map[string]string
+	values []string
+}
+
+// transitCacheable checks if the string should be cached.
+func transitCacheable(str string, asKey bool) bool {
+	return len(str) > 3 && (asKey || strings.HasPrefix(str, "~:") || strings.HasPrefix(str, "~$") || strings.HasPrefix(str, transitTag))
+}
+
+// transitCacheCode converts the cache index into the cache code.
+func transitCacheCode(index int) string {
+	if index < transitCacheBase {
+		return transitCacheRef + string(rune(index+'0'))
+	}
+	return transitCacheRef + string(rune(index/transitCacheBase+'0')) + string(rune(index%transitCacheBase+'0'))
+}
+
+// write returns the cache code if the string has been written before, otherwise the string is added to the cache.
+func (cache *transitCache) write(str string, asKey bool) string {
+	if transitCacheable(str, asKey) {
+		if code, has := cache.codes[str]; has {
+			return code
+		}
+
+		if cache.codes == nil || len(cache.codes) == transitCacheSize {
+			cache.codes = map[string]string{}
+		}
+		cache.codes[str] = transitCacheCode(len(cache.codes))
+	}
+	return str
+}
+
+// read resolves the cache code, otherwise the string is added to the cache.
+func (cache *transitCache) read(str string, asKey bool) (_ string, err error) {
+	if strings.HasPrefix(str, transitCacheRef) && str != transitMapAsArray {
+		index := 0
+		for _, r := range str[1:] {
+			index = index*transitCacheBase + int(r-'0')
+		}
+
+		if len(str) < 2 || len(str) > 3 || index < 0 || index >= len(cache.values) {
+			err = MakeErrorWithFormat(ErrParserError, "Unknown transit cache code: %s", str)
+		} else {
+			str = cache.values[index]
+		}
+	} else if transitCacheable(str, asKey) {
+		if len(cache.values) == transitCacheSize {
+			cache.values = nil
+		}
+		cache.values = append(cache.values, str)
+	}
+	return str, err
+}
+
+// transitEncoder walks the elements and writes them as transit onto the writer.
+type transitEncoder struct {
+	writer     io.Writer
+	serializer Serializer
+	cache      transitCache
+}
+
+// transitString serializes the primitive value as a complete transit document.
+func transitString(tag string, value interface{}) (string, error) {
+	var builder strings.Builder
+	err := (&transitEncoder{writer: &builder, serializer: TransitMimeType}).encodeTop(tag, value)
+	return builder.String(), err
+}
+
+// write the string onto the writer.
+func (enc *transitEncoder) write(str string) (err error) {
+	_, err = io.WriteString(enc.writer, str)
+	return err
+}
+
+// writeString writes the string as a JSON string, replacing it with the cache code when possible.
+func (enc *transitEncoder) writeString(str string, asKey bool) error {
+	return enc.write(jsonQuote(enc.cache.write(str, asKey)))
+}
+
+// isBuiltinTag checks if the tag is implied by the value, such as #inst for time values.
+func isBuiltinTag(tag string, value interface{}) (is bool) {
+	switch value.(type) {
+	case time.Time:
+		is = tag == InstantElementTag
+	case uuid.UUID:
+		is = tag == UUIDElementTag
+	case *url.URL:
+		is = tag == URIElementTag
+	case []byte:
+		is = tag == BytesElementTag
+	}
+	return is
+}
+
+// encodeTop writes the value as a complete document, scalar values are quoted since transit requires a collection
+// at the top level.
+func (enc *transitEncoder) encodeTop(tag string, value interface{}) (err error) {
+
+	if _, is := value.(*collectionElemImpl); is || (len(tag) > 0 && !isBuiltinTag(tag, value)) {
+		return enc.encode(tag, value, false)
+	}
+
+	if err = enc.write("["); err == nil {
+		if err = enc.writeString(transitTag+transitQuote, false); err == nil {
+			if err = enc.write(","); err == nil {
+				if err = enc.encode(tag, value, false); err == nil {
+					err = enc.write("]")
+				}
+			}
+		}
+	}
+
+	return err
+}
+
+// encode writes the tagged value, the value is either the value of a primitive element or the collection.
+func (enc *transitEncoder) encode(tag string, value interface{}, asKey bool) (err error) {
+
+	tagged := len(tag) > 0 && !isBuiltinTag(tag, value)
+	if tagged {
+		if err = enc.write("["); err == nil {
+			if err = enc.writeString(transitTag+tag, false); err == nil {
+				err = enc.write(",")
+			}
+		}
+		asKey = false
+	}
+
+	if err == nil {
+		if coll, is := value.(*collectionElemImpl); is {
+			err = enc.encodeCollection(coll)
+		} else {
+			err = enc.encodeScalar(value, asKey)
+		}
+	}
+
+	if err == nil && tagged {
+		err = enc.write("]")
+	}
+
+	return err
+}
+
+// encodeScalar writes the value of a primitive element. Map keys are always written as strings.
+func (enc *transitEncoder) encodeScalar(value interface{}, asKey bool) (err error) {
+
+	switch v := value.(type) {
+	case nil:
+		if asKey {
+			err = enc.writeString(transitEscape+"_", asKey)
+		} else {
+			err = enc.write("null")
+		}
+	case bool:
+		if asKey {
+			err = enc.writeString(transitEscape+"?"+strconv.FormatBool(v)[:1], asKey)
+		} else {
+			err = enc.write(strconv.FormatBool(v))
+		}
+	case int64:
+		if str := strconv.FormatInt(v, 10); asKey || v > transitMaxInt || v < -transitMaxInt {
+			err = enc.writeString(transitEscape+"i"+str, asKey)
+		} else {
+			err = enc.write(str)
+		}
+	case *big.Int:
+		err = enc.writeString(transitEscape+"n"+v.String(), asKey)
+	case *Decimal:
+		err = enc.writeString(transitEscape+"f"+v.String(), asKey)
+	case float64:
+		switch {
+		case math.IsNaN(v):
+			err = enc.writeString(transitEscape+"zNaN", asKey)
+		case math.IsInf(v, 1):
+			err = enc.writeString(transitEscape+"zINF", asKey)
+		case math.IsInf(v, -1):
+			err = enc.writeString(transitEscape+"z-INF", asKey)
+		default:
+			str := strconv.FormatFloat(v, 'g', -1, 64)
+			if !strings.ContainsAny(str, ".e") {
+				str += ".0"
+			}
+
+			if asKey {
+				err = enc.writeString(transitEscape+"d"+str, asKey)
+			} else {
+				err = enc.write(str)
+			}
+		}
+	case string:
+		if strings.HasPrefix(v, transitEscape) || strings.HasPrefix(v, transitCacheRef) || strings.HasPrefix(v, "`") {
+			v = transitEscape + v
+		}
+		err = enc.writeString(v, asKey)
+	case rune:
+		err = enc.writeString(transitEscape+"c"+string(v), asKey)
+	case time.Time:
+		err = enc.writeString(transitEscape+"t"+v.Format(time.RFC3339Nano), asKey)
+	case uuid.UUID:
+		err = enc.writeString(transitEscape+"u"+v.String(), asKey)
+	case *url.URL:
+		err = enc.writeString(transitEscape+"r"+v.String(), asKey)
+	case []byte:
+		err = enc.writeString(transitEscape+"b"+base64.StdEncoding.EncodeToString(v), asKey)
+	case SymbolElement:
+		if v.ElementType() == KeywordType {
+			err = enc.writeString(transitEscape+v.AppendNameOntoNamespace(v.Name()), asKey)
+		} else {
+			err = enc.writeString(transitEscape+"$"+v.AppendNameOntoNamespace(v.Name()), asKey)
+		}
+	default:
+		err = MakeErrorWithFormat(ErrInvalidInput, "Unsupported transit value: %T", v)
+	}
+
+	return err
+}
+
+// encodeCollection writes the collection, lists and sets are tagged and maps with keys that are not written as strings,
+// collections and tagged values, become cmaps.
+func (enc *transitEncoder) encodeCollection(coll *collectionElemImpl) (err error) {
+
+	hasKey := coll.ElementType() == MapType
+
+	var start, end string
+	switch coll.ElementType() {
+	case VectorType:
+		start, end = "[", "]"
+	case ListType:
+		start, end = "[", "]]"
+		err = enc.writeTagPrefix("list")
+	case SetType:
+		start, end = "[", "]]"
+		err = enc.writeTagPrefix("set")
+	case MapType:
+		start, end = "["+jsonQuote(transitMapAsArray), "]"
+
+		_ = coll.iterate(func(key Element, _ Element) error {
+			if _, is := key.(*collectionElemImpl); is || (key.HasTag() && !isBuiltinTag(key.Tag(), key.Value())) {
+				hasKey = false
+			}
+			return nil
+		})
+
+		if !hasKey {
+			start, end = "[", "]]"
+			err = enc.writeTagPrefix("cmap")
+		}
+	default:
+		err = MakeErrorWithFormat(ErrInvalidInput, "Unsupported transit collection: %s", coll.ElementType())
+	}
+
+	if err == nil {
+		err = enc.write(start)
+	}
+
+	var entries [][2]Element
+	if err == nil {
+		entries, err = coll.entries(enc.serializer)
+	}
+
+	first := coll.ElementType() != MapType || !hasKey
+	for _, children := range entries {
+		if coll.ElementType() != MapType {
+			children[0] = nil
+		}
+
+		for i, child := range children {
+			if child == nil {
+				continue
+			}
+
+			if first {
+				first = false
+			} else {
+				err = enc.write(",")
+			}
+
+			if err == nil {
+				err = enc.encode(child.Tag(), child.Value(), i == 0 && hasKey)
+			}
+
+			if err != nil {
+				break
+			}
+		}
+
+		if err != nil {
+			break
+		}
+	}
+
+	if err == nil {
+		err = enc.write(end)
+	}
+
+	return err
+}
+
+// writeTagPrefix opens the tagged value.
+func (enc *transitEncoder) writeTagPrefix(tag string) (err error) {
+	if err = enc.write("["); err == nil {
+		if err = enc.writeString(transitTag+tag, false); err == nil {
+			err = enc.write(",")
+		}
+	}
+	return err
+}
+
+// ParseTransit parses the transit+json data into an element.
+func ParseTransit(data string) (elem Element, err error) {
+
+	dec := &transitDecoder{decoder: json.NewDecoder(strings.NewReader(data))}
+	dec.decoder.UseNumber()
+
+	if elem, err = dec.decode(false); err == nil && dec.decoder.More() {
+		err = MakeError(ErrParserError, "Expected one transit value")
+	}
+
+	return elem, err
+}
+
+// transitDecoder reads the transit tokens into elements.
+type transitDecoder struct {
+	decoder *json.Decoder
+	cache   transitCache
+}
+
+// token reads the next JSON token.
+func (dec *transitDecoder) token() (token json.Token, err error) {
+	if token, err = dec.decoder.Token(); err != nil {
+		err = MakeError(ErrParserError, err)
+	}
+	return token, err
+}
+
+// decode reads the next value.
+func (dec *transitDecoder) decode(asKey bool) (elem Element, err error) {
+	var token json.Token
+	if token, err = dec.token(); err == nil {
+		elem, err = dec.decodeToken(token, asKey)
+	}
+	return elem, err
+}
+
+// decodeToken converts the token, reading the rest of the arrays and objects.
+func (dec *transitDecoder) decodeToken(token json.Token, asKey bool) (elem Element, err error) {
+
+	switch v := token.(type) {
+	case nil:
+		elem = NewNilElement()
+	case bool:
+		elem = NewBooleanElement(v)
+	case json.Number:
+		elem, err = fromJSON(v)
+	case string:
+		if v, err = dec.cache.read(v, asKey); err == nil {
+			elem, err = decodeTransitString(v)
+		}
+	case json.Delim:
+		switch v {
+		case '[':
+			elem, err = dec.decodeArray()
+		case '{':
+			elem, err = dec.decodeObject()
+		default:
+			err = MakeErrorWithFormat(ErrParserError, "Unexpected delimiter: %s", v)
+		}
+	}
+
+	return elem, err
+}
+
+// end reads the closing delimiter.
+func (dec *transitDecoder) end() (err error) {
+	var token json.Token
+	if token, err = dec.token(); err == nil {
+		if delim, is := token.(json.Delim); !is || (delim != ']' && delim != '}') {
+			err = MakeErrorWithFormat(ErrParserError, "Expected the end of the transit value: %v", token)
+		}
+	}
+	return err
+}
+
+// decodeArray reads the array as either a map, a tagged value or a vector.
+func (dec *transitDecoder) decodeArray() (elem Element, err error) {
+
+	var children []Element
+	if dec.decoder.More() {
+		var token json.Token
+		if token, err = dec.token(); err == nil {
+			if str, is := token.(string); is {
+				if str, err = dec.cache.read(str, false); err == nil {
+					switch {
+					case str == transitMapAsArray:
+						return dec.decodePairs()
+					case strings.HasPrefix(str, transitTag):
+						if elem, err = dec.decodeTagged(strings.TrimPrefix(str, transitTag)); err == nil {
+							err = dec.end()
+						}
+						return elem, err
+					default:
+						var child Element
+						if child, err = decodeTransitString(str); err == nil {
+							children = append(children, child)
+						}
+					}
+				}
+			} else {
+				var child Element
+				if child, err = dec.decodeToken(token, false); err == nil {
+					children = append(children, child)
+				}
+			}
+		}
+	}
+
+	for err == nil && dec.decoder.More() {
+		var child Element
+		if child, err = dec.decode(false); err == nil {
+			children = append(children, child)
+		}
+	}
+
+	if err == nil {
+		if err = dec.end(); err == nil {
+			elem, err = NewVector(children...)
+		}
+	}
+
+	return elem, err
+}
+
+// decodePairs reads the rest of the map as array.
+func (dec *transitDecoder) decodePairs() (elem Element, err error) {
+
+	var coll CollectionElement
+	if coll, err = NewMap(); err == nil {
+		for err == nil && dec.decoder.More() {
+			var key, value Element
+			if key, err = dec.decode(true); err == nil {
+				if value, err = dec.decode(false); err == nil {
+					err = coll.Append(key, value)
+				}
+			}
+		}
+
+		if err == nil {
+			elem, err = coll, dec.end()
+		}
+	}
+
+	return elem, err
+}
+
+// decodeObject reads the verbose form of a map or tagged value.
+func (dec *transitDecoder) decodeObject() (elem Element, err error) {
+
+	var coll CollectionElement
+	if coll, err = NewMap(); err == nil {
+		for first := true; err == nil && dec.decoder.More(); first = false {
+			var token json.Token
+			var key, value Element
+			if token, err = dec.token(); err == nil {
+				str, _ := token.(string)
+				if str, err = dec.cache.read(str, true); err == nil {
+					if first && strings.HasPrefix(str, transitTag) {
+						if elem, err = dec.decodeTagged(strings.TrimPrefix(str, transitTag)); err == nil {
+							err = dec.end()
+						}
+						return elem, err
+					}
+
+					if key, err = decodeTransitString(str); err == nil {
+						if value, err = dec.decode(false); err == nil {
+							err = coll.Append(key, value)
+						}
+					}
+				}
+			}
+		}
+
+		if err == nil {
+			elem, err = coll, dec.end()
+		}
+	}
+
+	return elem, err
+}
+
+// decodeTagged reads the value of the tag.
+func (dec *transitDecoder) decodeTagged(tag string) (elem Element, err error) {
+
+	if elem, err = dec.decode(false); err == nil {
+		switch tag {
+		case transitQuote:
+		case "list", "set", "cmap":
+			var children []Element
+			if vector, is := elem.(*collectionElemImpl); is && vector.ElementType() == VectorType {
+				children = vector.collection.([]Element)
+			} else {
+				err = MakeErrorWithFormat(ErrParserError, "Expected an array for the %s", tag)
+			}
+
+			if err == nil {
+				switch tag {
+				case "list":
+					elem, err = NewList(children...)
+				case "set":
+					elem, err = NewSet(children...)
+				default:
+					var coll CollectionElement
+					if coll, err = NewMap(); err == nil {
+						for i := 0; i+1 < len(children) && err == nil; i += 2 {
+							err = coll.Append(children[i], children[i+1])
+						}
+						elem = coll
+					}
+				}
+			}
+		default:
+			if err = elem.SetTag(tag); err == nil {
+				elem, err = readTagged(elem)
+			}
+		}
+	}
+
+	return elem, err
+}
+
+// decodeTransitString converts the encoded string into the element.
+func decodeTransitString(str string) (elem Element, err error) {
+
+	if !strings.HasPrefix(str, transitEscape) || len(str) < 2 {
+		return NewStringElement(str), nil
+	}
+
+	// e holds the errors from converting the value, these are reported as parser errors.
+	var e error
+	value := str[2:]
+	switch str[1] {
+	case '~', '^', '`':
+		elem = NewStringElement(str[1:])
+	case ':':
+		elem, err = NewKeywordElement(value)
+	case '$':
+		elem, err = NewSymbolElement(value)
+	case '_':
+		elem = NewNilElement()
+	case '?':
+		elem = NewBooleanElement(value == "t")
+	case 'i':
+		var i int64
+		if i, e = strconv.ParseInt(value, 10, 64); e == nil {
+			elem = NewIntegerElement(i)
+		}
+	case 'n':
+		elem, err = parseBigInt(value)
+	case 'f':
+		elem, err = parseBigDec(value)
+	case 'd':
+		var f float64
+		if f, e = strconv.ParseFloat(value, 64); e == nil {
+			elem = NewDoubleElement(f)
+		}
+	case 'z':
+		switch value {
+		case "NaN":
+			elem = NewDoubleElement(math.NaN())
+		case "INF":
+			elem = NewDoubleElement(math.Inf(1))
+		case "-INF":
+			elem = NewDoubleElement(math.Inf(-1))
+		default:
+			err = MakeErrorWithFormat(ErrParserError, "Unknown transit number: %s", str)
+		}
+	case 'c':
+		if r := []rune(value); len(r) == 1 {
+			elem = NewCharacterElement(r[0])
+		} else {
+			err = MakeErrorWithFormat(ErrParserError, "Invalid transit character: %s", str)
+		}
+	case 'm':
+		var millis int64
+		if millis, e = strconv.ParseInt(value, 10, 64); e == nil {
+			elem = NewInstantElement(time.Unix(0, millis*int64(time.Millisecond)).UTC())
+		}
+	case 't':
+		var t time.Time
+		if t, e = time.Parse(time.RFC3339Nano, value); e == nil {
+			elem = NewInstantElement(t)
+		}
+	case 'r':
+		var u *url.URL
+		if u, e = url.Parse(value); e == nil {
+			elem = NewURIElement(u)
+		}
+	case 'b':
+		var data []byte
+		if data, e = base64.StdEncoding.DecodeString(value); e == nil {
+			elem = NewBytesElement(data)
+		}
+	case 'u':
+		var id uuid.UUID
+		if id, e = uuid.ParseUUID(value); e == nil {
+			elem = NewUUIDElement(id)
+		}
+	default:
+		err = MakeErrorWithFormat(ErrParserError, "Unsupported transit value: %s", str)
+	}
+
+	if e != nil {
+		err = MakeErrorWithFormat(ErrParserError, "%s: %s", str, e)
+	}
+
+	return elem, err
+}
@@ -0,0 +1,200 @@
+// Copyright 2018-2019 Workiva Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package edn
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Workiva/eva-client-go/test"
+	"github.com/mattrobenolt/gocql/uuid"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Transit serialization", func() {
+
+	It("should be a known serializer", func() {
+		serializer, err := GetSerializer("application/transit+json")
+		Ω(err).Should(BeNil())
+		Ω(serializer.MimeType()).Should(BeEquivalentTo(TransitMimeType))
+	})
+
+	Context("primitives", func() {
+
+		keyword, _ := NewKeywordElement("book/title")
+		symbol, _ := NewSymbolElement("my/symbol")
+		id, _ := uuid.ParseUUID("f81d4fae-7dec-11d0-a765-00a0c91e6bf6")
+		inst := time.Date(1985, time.April, 12, 23, 20, 50, 0, time.UTC)
+
+		tests := map[string]Element{
+			`["~#'",null]`:                     NewNilElement(),
+			`["~#'",true]`:                     NewBooleanElement(true),
+			`["~#'",-42]`:                      NewIntegerElement(-42),
+			`["~#'","~i9007199254740993"]`:     NewIntegerElement(9007199254740993),
+			`["~#'",1.0]`:                      NewDoubleElement(1),
+			`["~#'","~zNaN"]`:                  NewDoubleElement(math.NaN()),
+			`["~#'","plain"]`:                  NewStringElement("plain"),
+			`["~#'","~~escaped"]`:              NewStringElement("~escaped"),
+			`["~#'","~ca"]`:                    NewCharacterElement('a'),
+			`["~#'","~:book/title"]`:           keyword,
+			`["~#'","~$my/symbol"]`:            symbol,
+			`["~#'","~t1985-04-12T23:20:50Z"]`: NewInstantElement(inst),
+			`["~#'","~uf81d4fae-7dec-11d0-a765-00a0c91e6bf6"]`: NewUUIDElement(id),
+		}
+
+		for expected, elem := range tests {
+			expected, elem := expected, elem
+			It("should serialize "+expected, func() {
+				str, err := elem.Serialize(TransitMimeType)
+				Ω(err).Should(BeNil())
+				Ω(str).Should(BeEquivalentTo(expected))
+
+				parsed, err := ParseTransit(str)
+				Ω(err).Should(BeNil())
+				Ω(parsed.ElementType()).Should(BeEquivalentTo(elem.ElementType()))
+				if elem.ElementType() != DoubleType {
+					Ω(parsed.Equals(elem)).Should(BeTrue())
+				}
+			})
+		}
+
+		It("should tag elements", func() {
+			elem := NewIntegerElement(1)
+			Ω(elem.SetTag("my/tag")).Should(BeNil())
+
+			str, err := elem.Serialize(TransitMimeType)
+			Ω(err).Should(BeNil())
+			Ω(str).Should(BeEquivalentTo(`["~#my/tag",1]`))
+		})
+	})
+
+	Context("collections", func() {
+
+		It("should serialize the collections", func() {
+			elem, err := Parse(`[(1) #{:a} {:b 2}]`)
+			Ω(err).Should(BeNil())
+
+			str, err := elem.Serialize(TransitMimeType)
+			Ω(err).Should(BeNil())
+			Ω(str).Should(BeEquivalentTo(`[["~#list",[1]],["~#set",["~:a"]],["^ ","~:b",2]]`))
+		})
+
+		It("should cache repeated keywords and map keys", func() {
+			elem, err := Parse(`[{:db/id 1 "name" "a"} {:db/id 2 "name" "b"} :db/id]`)
+			Ω(err).Should(BeNil())
+
+			str, err := elem.Serialize(TransitMimeType)
+			Ω(err).Should(BeNil())
+			Ω(strings.Count(str, `"~:db/id"`)).Should(BeEquivalentTo(1))
+			Ω(strings.Count(str, `"name"`)).Should(BeEquivalentTo(1))
+
+			parsed, err := ParseTransit(str)
+			Ω(err).Should(BeNil())
+			Ω(parsed.Equals(elem)).Should(BeTrue())
+		})
+
+		It("should write maps with collection keys as cmaps", func() {
+			elem, err := Parse(`{[1] 2}`)
+			Ω(err).Should(BeNil())
+
+			str, err := elem.Serialize(TransitMimeType)
+			Ω(err).Should(BeNil())
+			Ω(str).Should(BeEquivalentTo(`["~#cmap",[[1],2]]`))
+
+			parsed, err := ParseTransit(str)
+			Ω(err).Should(BeNil())
+			Ω(parsed.Equals(elem)).Should(BeTrue())
+		})
+
+		It("should write maps with tagged keys as cmaps", func() {
+			elem, err := Parse(`{#my/tag 1 2}`)
+			Ω(err).Should(BeNil())
+
+			str, err := elem.Serialize(TransitMimeType)
+			Ω(err).Should(BeNil())
+			Ω(str).Should(BeEquivalentTo(`["~#cmap",[["~#my/tag",1],2]]`))
+
+			parsed, err := ParseTransit(str)
+			Ω(err).Should(BeNil())
+			Ω(parsed.Equals(elem)).Should(BeTrue())
+		})
+
+		It("should keep the offset and precision of instants", func() {
+			elem, err := Parse(`{#inst "1985-04-12T23:20:50.123456789+02:00" #uuid "f81d4fae-7dec-11d0-a765-00a0c91e6bf6"}`)
+			Ω(err).Should(BeNil())
+
+			str, err := elem.Serialize(TransitMimeType)
+			Ω(err).Should(BeNil())
+			Ω(str).Should(BeEquivalentTo(`["^ ","~t1985-04-12T23:20:50.123456789+02:00","~uf81d4fae-7dec-11d0-a765-00a0c91e6bf6"]`))
+
+			parsed, err := ParseTransit(str)
+			Ω(err).Should(BeNil())
+			Ω(parsed.String()).Should(BeEquivalentTo(elem.String()))
+		})
+
+		It("should round trip scalar map keys", func() {
+			elem, err := Parse(`{1 nil true 1.5 "~x" :a}`)
+			Ω(err).Should(BeNil())
+
+			str, err := elem.Serialize(TransitMimeType)
+			Ω(err).Should(BeNil())
+
+			parsed, err := ParseTransit(str)
+			Ω(err).Should(BeNil())
+			Ω(parsed.Equals(elem)).Should(BeTrue())
+		})
+
+		It("should round trip past the cache size", func() {
+			var children []Element
+			for i := 0; i < transitCacheSize+100; i++ {
+				keyword, err := NewKeywordElement("key" + strconv.Itoa(i))
+				Ω(err).Should(BeNil())
+				children = append(children, keyword, keyword)
+			}
+
+			elem, err := NewVector(children...)
+			Ω(err).Should(BeNil())
+
+			str, err := elem.Serialize(TransitMimeType)
+			Ω(err).Should(BeNil())
+
+			parsed, err := ParseTransit(str)
+			Ω(err).Should(BeNil())
+			Ω(parsed.Equals(elem)).Should(BeTrue())
+		})
+	})
+
+	Context("parsing", func() {
+
+		It("should read the verbose form", func() {
+			elem, err := ParseTransit(`{"~:a": ["~t1985-04-12T23:20:50Z", {"~#set": [1]}]}`)
+			Ω(err).Should(BeNil())
+
+			expected, err := Parse(`{:a [#inst "1985-04-12T23:20:50Z" #{1}]}`)
+			Ω(err).Should(BeNil())
+			Ω(elem.Equals(expected)).Should(BeTrue())
+		})
+
+		It("should fail on bad input", func() {
+			for _, data := range []string{`["^ ", "~:a"`, `["^0"]`, `["~xfoo"]`, `["~#set", 1]`, `[1] [2]`} {
+				_, err := ParseTransit(data)
+				Ω(err).Should(test.HaveMessage(ErrParserError), data)
+			}
+		})
+	})
+})
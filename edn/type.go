@@ -119,20 +119,20 @@ var typeDefinitions = []*elementDefinition{
 	{SymbolType, initSymbol},
 	{KeywordType, initKeyword},
 	{IntegerType, initInteger},
+	{BigIntType, initBigInt},
 	{FloatType, initFloat},
+	{DoubleType, initDouble},
+	{BigDecType, initBigDec},
 	{InstantType, initInstant},
 	{UUIDType, initUUID},
+	{URIType, initURI},
+	{BytesType, initBytes},
 	{ListType, initList},
 	{VectorType, initVector},
 	{MapType, initMap},
 	{SetType, initSet},
 
 	// TODO
-	{URIType, nil},
-	{BytesType, nil},
-	{BigIntType, nil},
-	{BigDecType, nil},
-	{DoubleType, nil},
 	{RefType, nil},
 }
 
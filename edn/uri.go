@@ -0,0 +1,78 @@
+// Copyright 2018-2019 Workiva Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package edn
+
+import (
+	"net/url"
+)
+
+const (
+
+	// URIElementTag defines the uri tag value.
+	URIElementTag = "uri"
+)
+
+// uriStringProcessor used the string processor but will accurately create the uri.
+func uriStringProcessor(tokenValue string) (el Element, e error) {
+	var u *url.URL
+	if u, e = url.Parse(tokenValue); e == nil {
+		el = NewURIElement(u)
+	}
+
+	return el, e
+}
+
+// init will add the element factory to the collection of factories
+func initURI(_ Lexer) error {
+	return addElementTypeFactory(URIType, func(input interface{}) (elem Element, e error) {
+		if v, ok := input.(*url.URL); ok && v != nil {
+			elem = NewURIElement(v)
+		} else {
+			e = MakeError(ErrInvalidInput, input)
+		}
+		return elem, e
+	})
+}
+
+// NewURIElement creates a new uri element. The value is copied so later changes to the input do not affect the
+// element.
+func NewURIElement(value *url.URL) (elem Element) {
+
+	u := *value
+
+	var err error
+	if elem, err = baseFactory().make(&u, URIType, func(serializer Serializer, tag string, value interface{}) (out string, e error) {
+		switch serializer.MimeType() {
+		case EvaEdnMimeType:
+			if len(tag) > 0 {
+				out = TagPrefix + tag + " "
+			}
+			out += quoteString(value.(*url.URL).String())
+		case JSONMimeType:
+			out = jsonTagged(tag, jsonQuote(value.(*url.URL).String()))
+		case TransitMimeType:
+			out, e = transitString(tag, value)
+		default:
+			e = MakeError(ErrUnknownMimeType, serializer.MimeType())
+		}
+		return out, e
+	}); err == nil {
+		elem.SetTag(URIElementTag)
+	} else {
+		panic(err)
+	}
+
+	return elem
+}
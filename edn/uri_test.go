@@ -0,0 +1,150 @@
+// Copyright 2018-2019 Workiva Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package edn
+
+import (
+	"net/url"
+
+	"github.com/Workiva/eva-client-go/test"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("URI in EDN", func() {
+
+	link, _ := url.Parse("http://example.com/a?b=c#d")
+
+	Context("", func() {
+
+		It("should initialize without issue", func() {
+			lexer, err := newLexer()
+			Ω(err).Should(BeNil())
+
+			delete(typeFactories, URIType)
+			err = initURI(lexer)
+			Ω(err).Should(BeNil())
+			_, has := typeFactories[URIType]
+			Ω(has).Should(BeTrue())
+
+			err = initURI(lexer)
+			Ω(err).Should(test.HaveMessage(ErrInvalidFactory))
+		})
+
+		It("should create elements from the factory", func() {
+			elem, err := typeFactories[URIType](link)
+			Ω(err).Should(BeNil())
+			Ω(elem.ElementType()).Should(BeEquivalentTo(URIType))
+			Ω(elem.Value()).Should(BeEquivalentTo(link))
+		})
+
+		It("should not create elements from the factory if the input is not a the right type", func() {
+			elem, err := typeFactories[URIType]("http://example.com")
+			Ω(err).Should(test.HaveMessage(ErrInvalidInput))
+			Ω(elem).Should(BeNil())
+		})
+
+		It("should be created from a primitive", func() {
+			elem, err := NewPrimitiveElement(link)
+			Ω(err).Should(BeNil())
+			Ω(elem.ElementType()).Should(BeEquivalentTo(URIType))
+			Ω(elem.Tag()).Should(BeEquivalentTo(URIElementTag))
+		})
+
+		It("should copy the value", func() {
+			u := *link
+			elem := NewURIElement(&u)
+			u.Host = "other.com"
+			Ω(elem.Value().(*url.URL).Host).Should(BeEquivalentTo("example.com"))
+		})
+
+		It("should panic if the base factory errors.", func() {
+			origFac := baseFactory
+			baseFactory = func() elementFactory { return &breakerFactory{} }
+
+			wrapper := func() {
+				NewURIElement(link)
+			}
+
+			Ω(wrapper).Should(Panic())
+			baseFactory = origFac
+		})
+	})
+
+	Context("with the default marshaller", func() {
+
+		It("should serialize the uri as a quoted string", func() {
+			edn, err := NewURIElement(link).Serialize(EvaEdnMimeType)
+			Ω(err).Should(BeNil())
+			Ω(edn).Should(BeEquivalentTo(`#uri "http://example.com/a?b=c#d"`))
+		})
+
+		It("should not serialize to an unknown mime type", func() {
+			_, err := NewURIElement(link).Serialize(SerializerMimeType("InvalidType"))
+			Ω(err).Should(test.HaveMessage(ErrUnknownMimeType))
+		})
+
+		It("should round trip through json and transit", func() {
+			elem := NewURIElement(link)
+
+			str, err := elem.Serialize(JSONMimeType)
+			Ω(err).Should(BeNil())
+			Ω(str).Should(BeEquivalentTo(`{"#uri":"http://example.com/a?b=c#d"}`))
+
+			parsed, err := ParseJSON(str)
+			Ω(err).Should(BeNil())
+			Ω(parsed.Equals(elem)).Should(BeTrue())
+
+			str, err = elem.Serialize(TransitMimeType)
+			Ω(err).Should(BeNil())
+			Ω(str).Should(BeEquivalentTo(`["~#'","~rhttp://example.com/a?b=c#d"]`))
+
+			parsed, err = ParseTransit(str)
+			Ω(err).Should(BeNil())
+			Ω(parsed.Equals(elem)).Should(BeTrue())
+		})
+
+		It("should marshal and unmarshal urls", func() {
+			data, err := Marshal(struct {
+				Link url.URL `edn:"link"`
+			}{*link})
+			Ω(err).Should(BeNil())
+			Ω(string(data)).Should(BeEquivalentTo(`{:link #uri "http://example.com/a?b=c#d"}`))
+
+			var out struct {
+				Link  url.URL  `edn:"link"`
+				Other *url.URL `edn:"other"`
+			}
+			Ω(Unmarshal([]byte(`{:link #uri "http://example.com/a?b=c#d" :other "http://other.com"}`), &out)).Should(BeNil())
+			Ω(out.Link.String()).Should(BeEquivalentTo(link.String()))
+			Ω(out.Other.Host).Should(BeEquivalentTo("other.com"))
+		})
+	})
+
+	Context("Parsing", func() {
+
+		It("should parse the uri", func() {
+			elem, err := Parse(`#uri "http://example.com/a?b=c#d"`)
+			Ω(err).Should(BeNil())
+			Ω(elem.ElementType()).Should(BeEquivalentTo(URIType))
+			Ω(elem.Tag()).Should(BeEquivalentTo(URIElementTag))
+			Ω(elem.Equals(NewURIElement(link))).Should(BeTrue())
+		})
+
+		It("should not parse a bad uri", func() {
+			_, err := Parse(`#uri "http://[::1"`)
+			Ω(err).ShouldNot(BeNil())
+		})
+	})
+})
@@ -59,6 +59,10 @@ func NewUUIDElement(value uuid.UUID) (elem Element) {
 				out = TagPrefix + tag + " "
 			}
 			out += value.(uuid.UUID).String()
+		case JSONMimeType:
+			out = jsonTagged(tag, jsonQuote(value.(uuid.UUID).String()))
+		case TransitMimeType:
+			out, e = transitString(tag, value)
 		default:
 			e = MakeError(ErrUnknownMimeType, serializer.MimeType())
 		}
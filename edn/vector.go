@@ -58,7 +58,7 @@ func NewVector(elements ...Element) (elem CollectionElement, err error) {
 		}
 
 		var base *baseElemImpl
-		if base, err = baseFactory().make(coll, VectorType, collectionSerialization(false)); err == nil {
+		if base, err = makeCollectionBase(coll, VectorType, false); err == nil {
 			coll.baseElemImpl = base
 			elem = coll
 			err = elem.Append(elements...)
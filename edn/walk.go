@@ -0,0 +1,78 @@
+// Copyright 2018-2019 Workiva Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package edn
+
+// Walker is given an element and returns the element to replace it with, or nil to remove it from its collection.
+// Returning the element as is keeps it.
+type Walker func(elem Element) (Element, error)
+
+// Walk applies inner to the children of a collection, the keys and values of a map, and then applies outer to the
+// collection rebuilt from the results. The rebuilt collection has the same type and tag, the original is not changed.
+// Elements that are not collections are given to outer as is. Map keys that become equal keep the last value, as with
+// Assoc, and set items that become equal are kept once.
+func Walk(elem Element, inner Walker, outer Walker) (result Element, err error) {
+
+	coll, is := elem.(*collectionElemImpl)
+	if !is {
+		return outer(elem)
+	}
+
+	var rebuilt *collectionElemImpl
+	if rebuilt, err = coll.empty(); err == nil {
+		err = coll.iterate(func(key Element, value Element) (e error) {
+			if coll.ElementType() == MapType {
+				if key, e = inner(key); e == nil && key != nil {
+					if value, e = inner(value); e == nil && value != nil {
+						e = rebuilt.set(key, value)
+					}
+				}
+			} else if value, e = inner(value); e == nil && value != nil {
+				if coll.ElementType() != SetType || !rebuilt.Contains(value) {
+					e = rebuilt.Append(value)
+				}
+			}
+			return e
+		})
+	}
+
+	if err == nil {
+		result, err = outer(rebuilt)
+	}
+
+	return result, err
+}
+
+// Postwalk walks the element depth first, fn is given each element after its children have been replaced.
+func Postwalk(elem Element, fn Walker) (Element, error) {
+	return Walk(elem, func(child Element) (Element, error) {
+		return Postwalk(child, fn)
+	}, fn)
+}
+
+// Prewalk walks the element depth first, fn is given each element before its children and the children of the
+// replacement are walked.
+func Prewalk(elem Element, fn Walker) (result Element, err error) {
+	if result, err = fn(elem); err == nil && result != nil {
+		result, err = Walk(result, func(child Element) (Element, error) {
+			return Prewalk(child, fn)
+		}, identityWalker)
+	}
+	return result, err
+}
+
+// identityWalker keeps the element.
+func identityWalker(elem Element) (Element, error) {
+	return elem, nil
+}
@@ -0,0 +1,165 @@
+// Copyright 2018-2019 Workiva Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package edn
+
+import (
+	"github.com/Workiva/eva-client-go/test"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Walking in EDN", func() {
+
+	mustParse := func(str string) Element {
+		elem, err := Parse(str)
+		Ω(err).Should(BeNil())
+		return elem
+	}
+
+	Context("Walk", func() {
+
+		It("should apply inner to the children and outer to the result", func() {
+			double := func(elem Element) (Element, error) {
+				return NewIntegerElement(elem.Value().(int64) * 2), nil
+			}
+			count := func(elem Element) (Element, error) {
+				return NewIntegerElement(int64(elem.(CollectionElement).Len())), nil
+			}
+
+			elem := mustParse("#my/tag [1 2 3]")
+			var rebuilt Element
+			result, err := Walk(elem, double, func(elem Element) (Element, error) {
+				rebuilt = elem
+				return count(elem)
+			})
+			Ω(err).Should(BeNil())
+			Ω(result.String()).Should(BeEquivalentTo("3"))
+			Ω(rebuilt.String()).Should(BeEquivalentTo("#my/tag [2 4 6]"))
+			Ω(elem.String()).Should(BeEquivalentTo("#my/tag [1 2 3]"))
+
+			result, err = Walk(NewIntegerElement(4), double, double)
+			Ω(err).Should(BeNil())
+			Ω(result.String()).Should(BeEquivalentTo("8"))
+		})
+	})
+
+	Context("Postwalk", func() {
+
+		It("should visit the children before their parents", func() {
+			var visited []string
+			result, err := Postwalk(mustParse(`{:a [1 (2)] :b #{3}}`), func(elem Element) (Element, error) {
+				visited = append(visited, elem.String())
+				return elem, nil
+			})
+			Ω(err).Should(BeNil())
+			Ω(result.String()).Should(BeEquivalentTo(`{:a [1 (2)], :b #{3}}`))
+			Ω(visited).Should(Equal([]string{":a", "1", "2", "(2)", "[1 (2)]", ":b", "3", "#{3}", "{:a [1 (2)], :b #{3}}"}))
+		})
+
+		It("should rewrite the tempids", func() {
+			tx := mustParse(`[{:db/id #db/id [:db.part/user -1] :book/title "Emma"} [:db/add #db/id [:db.part/user -1] :book/author "Austen"]]`)
+
+			result, err := Postwalk(tx, func(elem Element) (Element, error) {
+				if elem.Tag() == "db/id" {
+					return NewIntegerElement(4711), nil
+				}
+				return elem, nil
+			})
+			Ω(err).Should(BeNil())
+			Ω(result.String()).Should(BeEquivalentTo(`[{:db/id 4711, :book/title "Emma"} [:db/add 4711 :book/author "Austen"]]`))
+		})
+
+		It("should strip the namespaces and remove elements", func() {
+			result, err := Postwalk(mustParse(`{:book/title "Emma" :book/secret "x" :book/tags [:genre/novel nil]}`), func(elem Element) (Element, error) {
+				switch {
+				case elem.ElementType() == NilType:
+					return nil, nil
+				case elem.ElementType() == KeywordType && elem.(SymbolElement).Name() == "secret":
+					return nil, nil
+				case elem.ElementType() == KeywordType:
+					return NewKeywordElement(elem.(SymbolElement).Name())
+				}
+				return elem, nil
+			})
+			Ω(err).Should(BeNil())
+			Ω(result.String()).Should(BeEquivalentTo(`{:title "Emma", :tags [:novel]}`))
+		})
+
+		It("should return the errors", func() {
+			_, err := Postwalk(mustParse("[1 [2]]"), func(elem Element) (Element, error) {
+				if elem.ElementType() == IntegerType && elem.Value().(int64) == 2 {
+					return nil, MakeError(ErrInvalidInput, "two")
+				}
+				return elem, nil
+			})
+			Ω(err).Should(test.HaveMessage(ErrInvalidInput))
+		})
+
+		It("should keep the last value of keys that become the same", func() {
+			result, err := Postwalk(mustParse("{:a/x 1 :b/x 2 :c/y 3}"), func(elem Element) (Element, error) {
+				if elem.ElementType() == KeywordType {
+					return NewKeywordElement(elem.(SymbolElement).Name())
+				}
+				return elem, nil
+			})
+			Ω(err).Should(BeNil())
+			Ω(result.String()).Should(BeEquivalentTo("{:x 2, :y 3}"))
+
+			result, err = Postwalk(mustParse("#{:a/x :b/x}"), func(elem Element) (Element, error) {
+				if elem.ElementType() == KeywordType {
+					return NewKeywordElement(elem.(SymbolElement).Name())
+				}
+				return elem, nil
+			})
+			Ω(err).Should(BeNil())
+			Ω(result.String()).Should(BeEquivalentTo("#{:x}"))
+		})
+	})
+
+	Context("Prewalk", func() {
+
+		It("should visit the parents before their children", func() {
+			var visited []string
+			_, err := Prewalk(mustParse(`[1 #t (2 3)]`), func(elem Element) (Element, error) {
+				visited = append(visited, elem.String())
+				return elem, nil
+			})
+			Ω(err).Should(BeNil())
+			Ω(visited).Should(Equal([]string{"[1 #t (2 3)]", "1", "#t (2 3)", "2", "3"}))
+		})
+
+		It("should walk the children of the replacement", func() {
+			result, err := Prewalk(mustParse(`[:secret "a"]`), func(elem Element) (Element, error) {
+				switch elem.ElementType() {
+				case KeywordType:
+					return NewVector(NewStringElement("b"), NewStringElement("c"))
+				case StringType:
+					return NewStringElement("***"), nil
+				}
+				return elem, nil
+			})
+			Ω(err).Should(BeNil())
+			Ω(result.String()).Should(BeEquivalentTo(`[["***" "***"] "***"]`))
+		})
+
+		It("should remove the root", func() {
+			result, err := Prewalk(mustParse("[1]"), func(Element) (Element, error) {
+				return nil, nil
+			})
+			Ω(err).Should(BeNil())
+			Ω(result).Should(BeNil())
+		})
+	})
+})
@@ -14,7 +14,11 @@
 
 package eva
 
-import "github.com/Workiva/eva-client-go/edn"
+import (
+	"context"
+
+	"github.com/Workiva/eva-client-go/edn"
+)
 
 // ConnectionChannel defines the channel to the eva connection
 type BaseConnectionChannel struct {
@@ -25,7 +29,7 @@ type BaseConnectionChannel struct {
 }
 
 type AsOfSnapshotImpl func(asOf edn.Serializable) (SnapshotChannel, error)
-type TransactImpl func(transaction edn.Serializable) (Result, error)
+type TransactImpl func(ctx context.Context, transaction edn.Serializable) (Result, error)
 
 func NewBaseConnectionChannel(label edn.Serializable, source Source, transactImpl TransactImpl, asOfSnapshotImpl AsOfSnapshotImpl) (channel *BaseConnectionChannel, err error) {
 
@@ -51,8 +55,14 @@ func NewBaseConnectionChannel(label edn.Serializable, source Source, transactImp
 }
 
 // Transact the data to the channel
-func (channel *BaseConnectionChannel) Transact(data ...interface{}) (result Result, err error) {
+func (channel *BaseConnectionChannel) Transact(data ...interface{}) (report *TxReport, err error) {
+	return channel.TransactContext(context.Background(), data...)
+}
+
+// TransactContext transacts the data to the channel, the call is abandoned when the context is done.
+func (channel *BaseConnectionChannel) TransactContext(ctx context.Context, data ...interface{}) (report *TxReport, err error) {
 
+	var result Result
 	var transactions []edn.Serializable
 	if len(data) > 0 {
 		for _, item := range data {
@@ -73,11 +83,15 @@ func (channel *BaseConnectionChannel) Transact(data ...interface{}) (result Resu
 
 	if err == nil && len(transactions) > 0 {
 		for _, trx := range transactions {
-			result, err = channel.transactImpl(trx)
+			result, err = channel.transactImpl(ctx, trx)
 		}
 	}
 
-	return result, err
+	if err == nil {
+		report, err = NewTxReport(result)
+	}
+
+	return report, err
 }
 
 // Label to this particular channel
@@ -42,7 +42,7 @@ var _ = Describe("base connection channel test", func() {
 			Ω(conn).ShouldNot(BeNil())
 			Ω(conn.Label()).Should(BeEquivalentTo("label"))
 
-			var result Result
+			var result *TxReport
 			result, err = conn.Transact("foo")
 			Ω(err).Should(BeNil())
 			Ω(result).ShouldNot(BeNil())
@@ -51,7 +51,8 @@ var _ = Describe("base connection channel test", func() {
 			var has bool
 			str, has = result.String()
 			Ω(has).Should(BeTrue())
-			Ω(str).Should(BeEquivalentTo("test"))
+			Ω(str).Should(BeEquivalentTo(mockTxReport))
+			Ω(result.DbAfter.BasisT).Should(BeEquivalentTo(2))
 
 			result, err = conn.Transact(edn.NewStringElement("trx"))
 			Ω(err).Should(BeNil())
@@ -59,7 +60,7 @@ var _ = Describe("base connection channel test", func() {
 
 			str, has = result.String()
 			Ω(has).Should(BeTrue())
-			Ω(str).Should(BeEquivalentTo("test"))
+			Ω(str).Should(BeEquivalentTo(mockTxReport))
 
 			result, err = conn.Transact()
 			Ω(err).ShouldNot(BeNil())
@@ -14,12 +14,16 @@
 
 package eva
 
-import "github.com/Workiva/eva-client-go/edn"
+import (
+	"context"
+
+	"github.com/Workiva/eva-client-go/edn"
+)
 
 type ConnectionChannelMaker func(label edn.Serializable, source Source) (channel ConnectionChannel, err error)
 
 // QueryImplementation defines the query implementation function.
-type QueryImplementation func(interface{}, ...interface{}) (Result, error)
+type QueryImplementation func(context.Context, interface{}, ...interface{}) (Result, error)
 
 // BaseSource defines the base source.
 type BaseSource struct {
@@ -110,5 +114,10 @@ func (source *BaseSource) AsOfSnapshot(label interface{}, asOf interface{}) (cha
 
 // Query the source for data.
 func (source *BaseSource) Query(query interface{}, parameters ...interface{}) (result Result, err error) {
-	return source.query(query, parameters...)
+	return source.QueryContext(context.Background(), query, parameters...)
+}
+
+// QueryContext queries the source for data, the call is abandoned when the context is done.
+func (source *BaseSource) QueryContext(ctx context.Context, query interface{}, parameters ...interface{}) (result Result, err error) {
+	return source.query(ctx, query, parameters...)
 }
@@ -15,6 +15,8 @@
 package eva
 
 import (
+	"context"
+
 	"github.com/Workiva/eva-client-go/edn"
 	"github.com/Workiva/eva-client-go/test"
 	. "github.com/onsi/ginkgo"
@@ -46,7 +48,7 @@ var _ = Describe("Base Channel", func() {
 		return channel, err
 	}
 
-	goodTransact := func(data edn.Serializable) (result Result, err error) {
+	goodTransact := func(_ context.Context, data edn.Serializable) (result Result, err error) {
 		return nil, nil
 	}
 
@@ -78,5 +80,25 @@ var _ = Describe("Base Channel", func() {
 			_, err = bcc.Transact(tenant, nil)
 			Ω(err).ShouldNot(BeNil())
 		})
+
+		It("should pass the context to the transact implementation", func() {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			var got context.Context
+			bcc, err := NewBaseConnectionChannel(label, &mockSource{}, func(c context.Context, _ edn.Serializable) (Result, error) {
+				got = c
+				return nil, c.Err()
+			}, asOfSnapshot)
+			Ω(err).Should(BeNil())
+
+			_, err = bcc.TransactContext(ctx, RawString("[]"))
+			Ω(err).Should(BeNil())
+			Ω(got).Should(BeIdenticalTo(ctx))
+
+			cancel()
+			_, err = bcc.TransactContext(ctx, RawString("[]"))
+			Ω(err).Should(Equal(context.Canceled))
+		})
 	})
 })
@@ -14,6 +14,8 @@
 
 package eva
 
+import "context"
+
 const (
 	// ConnectionReferenceType defines a new connection reference.
 	ConnectionReferenceType ChannelType = "eva.client.service/connection-ref"
@@ -24,7 +26,10 @@ type ConnectionChannel interface {
 	Channel
 
 	// Transact the data to the channel
-	Transact(data ...interface{}) (Result, error)
+	Transact(data ...interface{}) (*TxReport, error)
+
+	// TransactContext transacts the data to the channel, the call is abandoned when the context is done.
+	TransactContext(ctx context.Context, data ...interface{}) (*TxReport, error)
 
 	// LatestSnapshot returns the latest snapshot channel.
 	LatestSnapshot() (SnapshotChannel, error)
@@ -48,6 +48,9 @@ func decodeSerializable(item interface{}) (ser edn.Serializable, err error) {
 		case rawIntImpl:
 			ser = val
 			bad = false
+		case edn.Serializable:
+			ser = val
+			bad = false
 		}
 
 		if bad {
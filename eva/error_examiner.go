@@ -33,6 +33,9 @@ var codeKeyword edn.SymbolElement
 // ErrorExaminer will retrieve the error from the payload
 type ErrorExaminer func([]byte) error
 
+// ElementParser will parse the payload into an element.
+type ElementParser func([]byte) (edn.Element, error)
+
 // GetErrorExaminer will return the error examiner requested, or an error
 func GetErrorExaminer(serializer edn.Serializer) (examiner ErrorExaminer, err error) {
 
@@ -40,6 +43,10 @@ func GetErrorExaminer(serializer edn.Serializer) (examiner ErrorExaminer, err er
 		switch serializer.MimeType() {
 		case edn.EvaEdnMimeType:
 			examiner = ednErrorExaminer
+		case edn.JSONMimeType:
+			examiner = jsonErrorExaminer
+		case edn.TransitMimeType:
+			examiner = transitErrorExaminer
 		default:
 			err = edn.MakeError(ErrInvalidSerializer, serializer)
 		}
@@ -50,20 +57,78 @@ func GetErrorExaminer(serializer edn.Serializer) (examiner ErrorExaminer, err er
 	return examiner, err
 }
 
+// GetElementParser will return the element parser for the serializer requested, or an error
+func GetElementParser(serializer edn.Serializer) (parser ElementParser, err error) {
+
+	if serializer != nil {
+		switch serializer.MimeType() {
+		case edn.EvaEdnMimeType:
+			parser = ednElementParser
+		case edn.JSONMimeType:
+			parser = jsonElementParser
+		case edn.TransitMimeType:
+			parser = transitElementParser
+		default:
+			err = edn.MakeError(ErrInvalidSerializer, serializer)
+		}
+	} else {
+		parser = ednElementParser
+	}
+
+	return parser, err
+}
+
+// ednElementParser will parse the edn payload.
+func ednElementParser(body []byte) (edn.Element, error) {
+	return edn.Parse(string(body))
+}
+
+// jsonElementParser will parse the json payload.
+func jsonElementParser(body []byte) (edn.Element, error) {
+	return edn.ParseJSON(string(body))
+}
+
+// transitElementParser will parse the transit payload.
+func transitElementParser(body []byte) (edn.Element, error) {
+	return edn.ParseTransit(string(body))
+}
+
 // ednErrorExaminer will examine the payload for an error.
-func ednErrorExaminer(body []byte) (err error) {
-	var elem edn.Element
-	if elem, err = edn.Parse(string(body)); elem != nil {
+func ednErrorExaminer(body []byte) error {
+	return examineElement(ednElementParser(body))
+}
+
+// jsonErrorExaminer will examine the json payload for an error.
+func jsonErrorExaminer(body []byte) error {
+	return examineElement(jsonElementParser(body))
+}
+
+// transitErrorExaminer will examine the transit payload for an error.
+func transitErrorExaminer(body []byte) error {
+	return examineElement(transitElementParser(body))
+}
+
+// ExamineElement will examine an already parsed payload for an error.
+func ExamineElement(elem edn.Element) error {
+	return examineElement(elem, nil)
+}
+
+// examineElement will examine the parsed payload for an error. Maps without :ex-info, such as transaction reports,
+// are not errors.
+func examineElement(elem edn.Element, err error) error {
+	if elem != nil {
 		if elem.ElementType() == edn.MapType {
 			coll := elem.(edn.CollectionElement)
-			var exceptionElem edn.Element
-			if exceptionElem, err = coll.Get(exInfoKeyword); err == nil {
-				if elem.ElementType() == edn.MapType {
-					innerMap := exceptionElem.(edn.CollectionElement)
-
-					var code edn.Element
-					if code, err = innerMap.Get(codeKeyword); err == nil {
-						err = DecodeError(code)
+			if coll.Contains(exInfoKeyword) {
+				var exceptionElem edn.Element
+				if exceptionElem, err = coll.Get(exInfoKeyword); err == nil {
+					if exceptionElem.ElementType() == edn.MapType {
+						innerMap := exceptionElem.(edn.CollectionElement)
+
+						var code edn.Element
+						if code, err = innerMap.Get(codeKeyword); err == nil {
+							err = DecodeError(code)
+						}
 					}
 				}
 			}
@@ -43,6 +43,62 @@ var _ = Describe("Error examiner", func() {
 			Ω(examiner).ShouldNot(BeNil())
 			Ω(err).Should(BeNil())
 		})
+
+		It("with json mime type", func() {
+			examiner, err := GetErrorExaminer(edn.JSONMimeType)
+			Ω(examiner).ShouldNot(BeNil())
+			Ω(err).Should(BeNil())
+		})
+
+		It("with transit mime type", func() {
+			examiner, err := GetErrorExaminer(edn.TransitMimeType)
+			Ω(examiner).ShouldNot(BeNil())
+			Ω(err).Should(BeNil())
+		})
+	})
+
+	Context("GetElementParser", func() {
+		It("with nil", func() {
+			parser, err := GetElementParser(nil)
+			Ω(parser).ShouldNot(BeNil())
+			Ω(err).Should(BeNil())
+		})
+		It("with unknown", func() {
+			parser, err := GetElementParser(edn.SerializerMimeType("nothing"))
+			Ω(parser).Should(BeNil())
+			Ω(err).Should(test.HaveMessage(ErrInvalidSerializer))
+		})
+		It("with each mime type", func() {
+			for mime, body := range map[edn.SerializerMimeType]string{
+				edn.EvaEdnMimeType:  `[1]`,
+				edn.JSONMimeType:    `[1]`,
+				edn.TransitMimeType: `["~#list",[1]]`,
+			} {
+				parser, err := GetElementParser(mime)
+				Ω(err).Should(BeNil())
+
+				elem, err := parser([]byte(body))
+				Ω(err).Should(BeNil())
+				Ω(elem.ElementType().IsCollection()).Should(BeTrue())
+			}
+		})
+	})
+
+	Context("ExamineElement", func() {
+		It("should find the error in a parsed payload", func() {
+			elem, err := edn.Parse(`{:ex-info {:code 3000}}`)
+			Ω(err).Should(BeNil())
+			Ω(ExamineElement(elem)).Should(BeAssignableToTypeOf(&clientErrorImpl{}))
+		})
+		It("should ignore other payloads", func() {
+			elem, err := edn.Parse(`[{:db/id 1}]`)
+			Ω(err).Should(BeNil())
+			Ω(ExamineElement(elem)).Should(BeNil())
+
+			elem, err = edn.Parse(`{:tempids {-1 42}}`)
+			Ω(err).Should(BeNil())
+			Ω(ExamineElement(elem)).Should(BeNil())
+		})
 	})
 
 	Context("ednErrorExaminer", func() {
@@ -77,4 +133,43 @@ var _ = Describe("Error examiner", func() {
 			Ω(clientErr.Error()).Should(ContainSubstring(ErrSourceError.Message()))
 		})
 	})
+
+	Context("jsonErrorExaminer", func() {
+		It("with empty", func() {
+			err := jsonErrorExaminer([]byte(""))
+			Ω(err).ShouldNot(BeNil())
+		})
+
+		It("with no error", func() {
+			err := jsonErrorExaminer([]byte(`[{":db/id": 1}]`))
+			Ω(err).Should(BeNil())
+		})
+
+		It("with error", func() {
+
+			example := []byte(`{
+	"message": "",
+	":ex-info": {
+		":explanation": "Malformed transact request.",
+		":type": "IncorrectTransactSyntax",
+		":code": 3000},
+	":ex-data": ""
+}`)
+
+			err := jsonErrorExaminer(example)
+			Ω(err).ShouldNot(BeNil())
+			Ω(err).Should(BeAssignableToTypeOf(&clientErrorImpl{}))
+			Ω(err.Error()).Should(ContainSubstring(ErrSourceError.Message()))
+		})
+	})
+
+	Context("transitErrorExaminer", func() {
+		It("with error", func() {
+			example := []byte(`["^ ","~:message","","~:ex-info",["^ ","~:code",3000],"~:ex-data",""]`)
+
+			err := transitErrorExaminer(example)
+			Ω(err).Should(BeAssignableToTypeOf(&clientErrorImpl{}))
+			Ω(err.Error()).Should(ContainSubstring(ErrSourceError.Message()))
+		})
+	})
 })
@@ -74,7 +74,11 @@ var _ = Describe("Connection Channel Test", func() {
 			Ω(source).ShouldNot(BeNil())
 
 			if httpSource, is := source.(*httpSourceImpl); is {
-				httpSource.callClient = fakeGoodCaller(edn.EvaEdnMimeType.String())
+				httpSource.callClient = fakeBodyCaller(edn.EvaEdnMimeType.String(), `{
+					:tempids {-1 4398046511105}
+					:db-before #eva.client.service/snapshot-ref {:label "test" :as-of 1}
+					:db-after #eva.client.service/snapshot-ref {:label "test" :as-of 2}
+					:tx-data (#datom [4398046511105 3 "value" 4398046511106 true])}`)
 				label := edn.NewStringElement("test")
 
 				channel, err := newHttpConnChannel(label, source)
@@ -84,6 +88,17 @@ var _ = Describe("Connection Channel Test", func() {
 				result, err := channel.Transact("")
 				Ω(err).Should(BeNil())
 				Ω(result).ShouldNot(BeNil())
+				id, has := result.ResolveTempID(-1)
+				Ω(has).Should(BeTrue())
+				Ω(id).Should(BeEquivalentTo(4398046511105))
+				Ω(result.DbAfter).Should(Equal(eva.SnapshotRef{Label: "test", BasisT: 2}))
+				Ω(result.TxData).Should(HaveLen(1))
+
+				httpSource.callClient = fakeGoodCaller(edn.EvaEdnMimeType.String())
+				result, err = channel.Transact("")
+				Ω(err).Should(test.HaveMessage(eva.ErrInvalidTxReport))
+				Ω(result).ShouldNot(BeNil())
+				Ω(result.Result).ShouldNot(BeNil())
 			} else {
 				Fail("Expected the binding to be a *httpSourceImpl")
 			}
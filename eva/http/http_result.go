@@ -20,6 +20,7 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/url"
+	"sync"
 )
 
 const (
@@ -32,7 +33,12 @@ type httpResult struct {
 	body        []byte
 	code        int
 	contentType string
-	examine     eva.ErrorExaminer
+	headers     http.Header
+	parse       eva.ElementParser
+
+	parseOnce sync.Once
+	elem      edn.Element
+	parseErr  error
 }
 
 func newHttpResult(req *http.Request, form url.Values, resp *http.Response) (result eva.Result, err error) {
@@ -53,9 +59,9 @@ func newHttpResult(req *http.Request, form url.Values, resp *http.Response) (res
 		serializer = edn.DefaultMimeType
 	}
 
-	var examiner eva.ErrorExaminer
+	var parser eva.ElementParser
 	if err == nil {
-		examiner, err = eva.GetErrorExaminer(serializer)
+		parser, err = eva.GetElementParser(serializer)
 	}
 
 	if err == nil {
@@ -63,7 +69,8 @@ func newHttpResult(req *http.Request, form url.Values, resp *http.Response) (res
 			body:        data,
 			code:        resp.StatusCode,
 			contentType: contentType,
-			examine:     examiner,
+			headers:     resp.Header,
+			parse:       parser,
 		}
 	}
 
@@ -100,13 +107,16 @@ func (result *httpResult) String() (string, bool) {
 // Error of this result.
 func (result *httpResult) Error() (err error, _ bool) {
 
-	if result.examine != nil {
+	if result.parse != nil {
 		if result.code < http.StatusOK || result.code >= http.StatusBadRequest {
 			err = edn.MakeError(ErrServiceError, result)
 		}
 
 		if err == nil {
-			err = result.examine(result.body)
+			var elem edn.Element
+			if elem, err = result.Element(); err == nil {
+				err = eva.ExamineElement(elem)
+			}
 		}
 	} else {
 		err = edn.MakeErrorWithFormat(eva.ErrInvalidSerializer, "Unsupported return type: %s", result.contentType)
@@ -114,3 +124,44 @@ func (result *httpResult) Error() (err error, _ bool) {
 
 	return err, err != nil
 }
+
+// Element of this result, the body is parsed on the first call and cached.
+func (result *httpResult) Element() (edn.Element, error) {
+
+	if result.parse != nil {
+		result.parseOnce.Do(func() {
+			result.elem, result.parseErr = result.parse(result.body)
+		})
+		return result.elem, result.parseErr
+	}
+
+	return nil, edn.MakeErrorWithFormat(eva.ErrInvalidSerializer, "Unsupported return type: %s", result.contentType)
+}
+
+// Decode this result into the value pointed to by v.
+func (result *httpResult) Decode(v interface{}) (err error) {
+
+	if err, _ = result.Error(); err == nil {
+		var elem edn.Element
+		if elem, err = result.Element(); err == nil {
+			err = edn.UnmarshalElement(elem, v)
+		}
+	}
+
+	return err
+}
+
+// StatusCode of this result.
+func (result *httpResult) StatusCode() int {
+	return result.code
+}
+
+// ContentType of this result.
+func (result *httpResult) ContentType() string {
+	return result.contentType
+}
+
+// Headers of this result.
+func (result *httpResult) Headers() map[string][]string {
+	return result.headers
+}
@@ -0,0 +1,138 @@
+// Copyright 2018-2019 Workiva Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/Workiva/eva-client-go/edn"
+	"github.com/Workiva/eva-client-go/eva"
+	"github.com/Workiva/eva-client-go/test"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Http result", func() {
+
+	response := func(contentType string, body string) *http.Response {
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header: map[string][]string{
+				"Content-Type": {contentType},
+			},
+			Body: ioutil.NopCloser(strings.NewReader(body)),
+		}
+	}
+
+	Context("with json", func() {
+		It("should read the result", func() {
+			result, err := newHttpResult(nil, nil, response("application/json; charset=utf-8", `[{":db/id": 1}]`))
+			Ω(err).Should(BeNil())
+
+			err, has := result.Error()
+			Ω(err).Should(BeNil())
+			Ω(has).Should(BeFalse())
+
+			str, has := result.String()
+			Ω(str).Should(BeEquivalentTo(`[{":db/id": 1}]`))
+			Ω(has).Should(BeTrue())
+		})
+
+		It("should decode the error", func() {
+			result, err := newHttpResult(nil, nil, response("application/json", `{":ex-info": {":code": 3000}}`))
+			Ω(err).Should(BeNil())
+
+			err, has := result.Error()
+			Ω(has).Should(BeTrue())
+			Ω(err.Error()).Should(ContainSubstring(eva.ErrSourceError.Message()))
+		})
+
+		It("should expose the response metadata", func() {
+			resp := response("application/json", `[]`)
+			resp.StatusCode = http.StatusCreated
+			resp.Header.Add("X-Test", "val")
+
+			result, err := newHttpResult(nil, nil, resp)
+			Ω(err).Should(BeNil())
+			Ω(result.StatusCode()).Should(Equal(http.StatusCreated))
+			Ω(result.ContentType()).Should(Equal("application/json"))
+			Ω(http.Header(result.Headers()).Get("X-Test")).Should(Equal("val"))
+		})
+	})
+
+	Context("with edn", func() {
+		It("should parse the element once", func() {
+			result, err := newHttpResult(nil, nil, response(edn.EvaEdnMimeType.String(), `{:db/id 1 :book/title "Dune"}`))
+			Ω(err).Should(BeNil())
+
+			elem, err := result.Element()
+			Ω(err).Should(BeNil())
+			Ω(elem.ElementType()).Should(Equal(edn.MapType))
+
+			again, err := result.Element()
+			Ω(err).Should(BeNil())
+			Ω(again).Should(BeIdenticalTo(elem))
+		})
+
+		It("should decode into go values", func() {
+			result, err := newHttpResult(nil, nil, response(edn.EvaEdnMimeType.String(), `[{:db/id 1 :book/title "Dune"}]`))
+			Ω(err).Should(BeNil())
+
+			var books []struct {
+				ID    int64  `edn:"db/id"`
+				Title string `edn:"book/title"`
+			}
+			Ω(result.Decode(&books)).Should(Succeed())
+			Ω(books).Should(HaveLen(1))
+			Ω(books[0].ID).Should(BeEquivalentTo(1))
+			Ω(books[0].Title).Should(Equal("Dune"))
+
+			var generic interface{}
+			Ω(result.Decode(&generic)).Should(Succeed())
+			Ω(generic).Should(HaveLen(1))
+		})
+
+		It("should return the parse error", func() {
+			result, err := newHttpResult(nil, nil, response(edn.EvaEdnMimeType.String(), `[1 2`))
+			Ω(err).Should(BeNil())
+
+			_, err = result.Element()
+			Ω(err).ShouldNot(BeNil())
+
+			var v interface{}
+			Ω(result.Decode(&v)).ShouldNot(Succeed())
+		})
+
+		It("should return the call error when decoding", func() {
+			resp := response(edn.EvaEdnMimeType.String(), `{:ex-info {:code 3000}}`)
+			result, err := newHttpResult(nil, nil, resp)
+			Ω(err).Should(BeNil())
+
+			var v interface{}
+			err = result.Decode(&v)
+			Ω(err).ShouldNot(BeNil())
+			Ω(err.Error()).Should(ContainSubstring(eva.ErrSourceError.Message()))
+			Ω(v).Should(BeNil())
+
+			resp = response(edn.EvaEdnMimeType.String(), `[]`)
+			resp.StatusCode = http.StatusInternalServerError
+			result, err = newHttpResult(nil, nil, resp)
+			Ω(err).Should(BeNil())
+			Ω(result.Decode(&v)).Should(test.HaveMessage(ErrServiceError))
+		})
+	})
+})
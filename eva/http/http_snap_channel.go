@@ -15,6 +15,7 @@
 package http
 
 import (
+	"context"
 	"github.com/Workiva/eva-client-go/edn"
 	"github.com/Workiva/eva-client-go/eva"
 	"net/http"
@@ -42,7 +43,7 @@ func newHttpSnapChannel(connChan *httpConnChanImpl, t edn.Serializable) (channel
 	return channel, err
 }
 
-func (snap *httpSnapChanImpl) invoke(function edn.Serializable, parameters ...interface{}) (result eva.Result, err error) {
+func (snap *httpSnapChanImpl) invoke(ctx context.Context, function edn.Serializable, parameters ...interface{}) (result eva.Result, err error) {
 	uri := snap.connChan.Source().(*httpSourceImpl).formulateUrl("invoke")
 
 	var serializer edn.Serializer
@@ -57,7 +58,7 @@ func (snap *httpSnapChanImpl) invoke(function edn.Serializable, parameters ...in
 					var str string
 					if str, err = ref.Serialize(serializer); err == nil {
 						form.Add("reference", str)
-						result, err = snap.connChan.Source().(*httpSourceImpl).call(http.MethodPost, uri, form)
+						result, err = snap.connChan.Source().(*httpSourceImpl).call(ctx, http.MethodPost, uri, form)
 					}
 				}
 			}
@@ -67,7 +68,7 @@ func (snap *httpSnapChanImpl) invoke(function edn.Serializable, parameters ...in
 	return result, err
 }
 
-func (snap *httpSnapChanImpl) pull(pattern edn.Serializable, ids edn.Serializable, params ...interface{}) (result eva.Result, err error) {
+func (snap *httpSnapChanImpl) pull(ctx context.Context, pattern edn.Serializable, ids edn.Serializable, params ...interface{}) (result eva.Result, err error) {
 
 	uri := snap.connChan.Source().(*httpSourceImpl).formulateUrl("pull")
 	form := url.Values{}
@@ -103,7 +104,7 @@ func (snap *httpSnapChanImpl) pull(pattern edn.Serializable, ids edn.Serializabl
 	}
 
 	if err == nil {
-		result, err = snap.connChan.Source().(*httpSourceImpl).call(http.MethodPost, uri, form)
+		result, err = snap.connChan.Source().(*httpSourceImpl).call(ctx, http.MethodPost, uri, form)
 	}
 
 	return result, err
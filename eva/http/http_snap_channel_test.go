@@ -15,6 +15,7 @@
 package http
 
 import (
+	"context"
 	"github.com/Workiva/eva-client-go/edn"
 	"github.com/Workiva/eva-client-go/eva"
 	. "github.com/onsi/ginkgo"
@@ -68,17 +69,17 @@ var _ = Describe("Connection Channel Test", func() {
 				Ω(tenant).ShouldNot(BeNil())
 
 				httpSnap := snap.(*httpSnapChanImpl)
-				result, err := httpSnap.invoke(f)
+				result, err := httpSnap.invoke(context.Background(), f)
 				Ω(err).Should(BeNil())
 				Ω(result).ShouldNot(BeNil())
 
 				pattern := edn.NewStringElement("f")
 
-				result, err = httpSnap.pull(pattern, eva.RawString("param"))
+				result, err = httpSnap.pull(context.Background(), pattern, eva.RawString("param"))
 				Ω(err).Should(BeNil())
 				Ω(result).ShouldNot(BeNil())
 
-				result, err = httpSnap.pull(pattern, eva.RawString("params"), &struct{}{})
+				result, err = httpSnap.pull(context.Background(), pattern, eva.RawString("params"), &struct{}{})
 				Ω(err).ShouldNot(BeNil())
 				Ω(result).Should(BeNil())
 			} else {
@@ -15,6 +15,7 @@
 package http
 
 import (
+	"context"
 	"crypto/tls"
 	"crypto/x509"
 	"fmt"
@@ -163,10 +164,13 @@ func (source *httpSourceImpl) formulateUrl(operation string) string {
 		source.BaseSource.Category())
 }
 
-// call the uri with the provided form.
-func (source *httpSourceImpl) call(method string, uri string, form url.Values) (result eva.Result, err error) {
+// call the uri with the provided form, the call and its retries are abandoned when the context is done.
+func (source *httpSourceImpl) call(ctx context.Context, method string, uri string, form url.Values) (result eva.Result, err error) {
 
-	if source.callClient != nil {
+	switch {
+	case ctx == nil:
+		err = edn.MakeError(edn.ErrInvalidInput, "nil context")
+	case source.callClient != nil:
 		var req *http.Request
 		client := &http.Client{}
 
@@ -178,6 +182,7 @@ func (source *httpSourceImpl) call(method string, uri string, form url.Values) (
 					req.Header.Add("_cid", corrId)
 				}
 
+				req = req.WithContext(ctx)
 				req.Header.Add("Content-Type", XFormContentType)
 				req.Header.Add("Accept", serializer.MimeType().String())
 
@@ -196,10 +201,12 @@ func (source *httpSourceImpl) call(method string, uri string, form url.Values) (
 			for tries := 0; tries < source.retryTimes && err == nil && !done; tries++ {
 
 				var resp *http.Response
-				if resp, err = source.callClient(client, req); err == nil {
+				if err = ctx.Err(); err == nil {
+					if resp, err = source.callClient(client, req); err == nil {
 
-					done = true // At this point the request was made and server responded.
-					result, err = newHttpResult(req, form, resp)
+						done = true // At this point the request was made and server responded.
+						result, err = newHttpResult(req, form, resp)
+					}
 				}
 
 				switch e := err.(type) {
@@ -217,20 +224,25 @@ func (source *httpSourceImpl) call(method string, uri string, form url.Values) (
 						strings.Contains(errMsg, "connect: connection refused"):
 
 						// For all these cases, just pause and try again.
-						time.Sleep(source.retryPause)
+						select {
+						case <-ctx.Done():
+						case <-time.After(source.retryPause):
+						}
 					}
 				}
 
-				// clear the error if needed.
+				// clear the error if needed, a done context is never retried.
 				if err != nil {
-					if tries+1 < source.retryTimes {
+					if ctxErr := ctx.Err(); ctxErr != nil {
+						err = ctxErr
+					} else if tries+1 < source.retryTimes {
 						err = nil
 					}
 				}
 			}
 		}
 
-	} else {
+	default:
 		err = edn.MakeError(ErrNoServiceImpl, "")
 	}
 
@@ -238,7 +250,7 @@ func (source *httpSourceImpl) call(method string, uri string, form url.Values) (
 }
 
 // queryImpl implements the query.
-func (source *httpSourceImpl) queryImpl(query interface{}, parameters ...interface{}) (result eva.Result, err error) {
+func (source *httpSourceImpl) queryImpl(ctx context.Context, query interface{}, parameters ...interface{}) (result eva.Result, err error) {
 	form := url.Values{}
 
 	if err == nil {
@@ -258,7 +270,7 @@ func (source *httpSourceImpl) queryImpl(query interface{}, parameters ...interfa
 			form.Add("query", trx)
 			if err = source.fillForm(form, parameters...); err == nil {
 				uri := source.formulateUrl("q")
-				result, err = source.call(http.MethodPost, uri, form)
+				result, err = source.call(ctx, http.MethodPost, uri, form)
 			}
 		}
 	}
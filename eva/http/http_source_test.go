@@ -15,6 +15,7 @@
 package http
 
 import (
+	"context"
 	"fmt"
 	"io/ioutil"
 	"net/http"
@@ -32,6 +33,7 @@ import (
 type fakeClient struct {
 	contentType string
 	status      int
+	body        string
 }
 
 type fakeCaller struct {
@@ -42,13 +44,18 @@ type fakeCaller struct {
 }
 
 func (c *fakeClient) Do(req *http.Request) (*http.Response, error) {
+	body := "[]"
+	if len(c.body) > 0 {
+		body = c.body
+	}
+
 	resp := &http.Response{
 		Status:     "Testing",
 		StatusCode: c.status,
 		Header: map[string][]string{
 			"test": {"val"},
 		},
-		Body: ioutil.NopCloser(strings.NewReader("[]")),
+		Body: ioutil.NopCloser(strings.NewReader(body)),
 	}
 
 	if len(c.contentType) > 0 {
@@ -102,6 +109,17 @@ func fakeGoodCaller(contentType string) func(c httpDoer, r *http.Request) (*http
 	}
 }
 
+func fakeBodyCaller(contentType string, body string) func(c httpDoer, r *http.Request) (*http.Response, error) {
+	return func(c httpDoer, r *http.Request) (*http.Response, error) {
+		f := &fakeClient{
+			status:      http.StatusOK,
+			contentType: contentType,
+			body:        body,
+		}
+		return f.Do(r)
+	}
+}
+
 var (
 	fakeBadCaller = func(c httpDoer, r *http.Request) (*http.Response, error) {
 		f := &fakeClient{
@@ -482,7 +500,7 @@ var _ = Describe("Binding Test", func() {
 				form := url.Values{}
 
 				form.Add("foo", "bar")
-				res, err := httpSource.call("GET", "http://localhost", form)
+				res, err := httpSource.call(context.Background(), "GET", "http://localhost", form)
 				Ω(err).ShouldNot(BeNil())
 				Ω(err).Should(test.HaveMessage(ErrNoServiceImpl))
 				Ω(res).Should(BeNil())
@@ -522,7 +540,7 @@ var _ = Describe("Binding Test", func() {
 				form := url.Values{}
 
 				form.Add("foo", "bar")
-				res, err := httpSource.call("GET", "http://localhost", form)
+				res, err := httpSource.call(context.Background(), "GET", "http://localhost", form)
 				Ω(err).Should(BeNil())
 				Ω(res).ShouldNot(BeNil())
 
@@ -565,7 +583,7 @@ var _ = Describe("Binding Test", func() {
 				form := url.Values{}
 
 				form.Add("foo", "bar")
-				res, err := httpSource.call("GET", "http://localhost", form)
+				res, err := httpSource.call(context.Background(), "GET", "http://localhost", form)
 				Ω(err).Should(BeNil())
 				Ω(res).ShouldNot(BeNil())
 
@@ -609,12 +627,12 @@ var _ = Describe("Binding Test", func() {
 				form := url.Values{}
 
 				form.Add("foo", "bar")
-				res, err := httpSource.call("GET", "http://localhost", form)
+				res, err := httpSource.call(context.Background(), "GET", "http://localhost", form)
 				Ω(err).Should(BeNil())
 				Ω(res).ShouldNot(BeNil())
 
 				// mess with the result.
-				res.(*httpResult).examine = nil
+				res.(*httpResult).parse = nil
 
 				var has bool
 				err, has = res.Error()
@@ -658,7 +676,7 @@ var _ = Describe("Binding Test", func() {
 				form := url.Values{}
 
 				form.Add("foo", "bar")
-				res, err := httpSource.call("GET", "http://localhost", form)
+				res, err := httpSource.call(context.Background(), "GET", "http://localhost", form)
 				Ω(err).Should(BeNil())
 				Ω(res).ShouldNot(BeNil())
 			} else {
@@ -703,7 +721,7 @@ var _ = Describe("Binding Test", func() {
 				form := url.Values{}
 
 				form.Add("foo", "bar")
-				res, err := httpSource.call("GET", "http://localhost", form)
+				res, err := httpSource.call(context.Background(), "GET", "http://localhost", form)
 				Ω(err).Should(BeNil())
 				Ω(res).ShouldNot(BeNil())
 				Ω(f.callCount).Should(BeEquivalentTo(tries))
@@ -745,7 +763,7 @@ var _ = Describe("Binding Test", func() {
 				form := url.Values{}
 
 				form.Add("foo", "bar")
-				res, err := httpSource.call("GET", "http://localhost", form)
+				res, err := httpSource.call(context.Background(), "GET", "http://localhost", form)
 				Ω(err).Should(BeNil())
 				Ω(res).ShouldNot(BeNil())
 
@@ -785,7 +803,7 @@ var _ = Describe("Binding Test", func() {
 				form := url.Values{}
 
 				form.Add("foo", "bar")
-				res, err := httpSource.call("GET", "http://localhost", form)
+				res, err := httpSource.call(context.Background(), "GET", "http://localhost", form)
 				Ω(err).Should(BeNil())
 				Ω(res).ShouldNot(BeNil())
 
@@ -826,7 +844,7 @@ var _ = Describe("Binding Test", func() {
 			if httpSource, is := source.(*httpSourceImpl); is {
 				httpSource.callClient = fakeGoodCaller(edn.EvaEdnMimeType.String())
 
-				res, err := httpSource.queryImpl(edn.NewStringElement("foo"))
+				res, err := httpSource.queryImpl(context.Background(), edn.NewStringElement("foo"))
 				Ω(err).Should(BeNil())
 				Ω(res).ShouldNot(BeNil())
 			} else {
@@ -862,7 +880,7 @@ var _ = Describe("Binding Test", func() {
 			if httpSource, is := source.(*httpSourceImpl); is {
 				httpSource.callClient = fakeGoodCaller(edn.EvaEdnMimeType.String())
 
-				res, err := httpSource.queryImpl("\"foo\"")
+				res, err := httpSource.queryImpl(context.Background(), "\"foo\"")
 				Ω(err).Should(BeNil())
 				Ω(res).ShouldNot(BeNil())
 			} else {
@@ -900,7 +918,7 @@ var _ = Describe("Binding Test", func() {
 				tenant, err := eva.NewTenant("tenant")
 				Ω(err).Should(BeNil())
 
-				res, err := httpSource.queryImpl(tenant, 42)
+				res, err := httpSource.queryImpl(context.Background(), tenant, 42)
 				Ω(err).ShouldNot(BeNil())
 				Ω(err).Should(test.HaveMessage(ErrUnsupportedType))
 				Ω(res).Should(BeNil())
@@ -1052,4 +1070,109 @@ var _ = Describe("Binding Test", func() {
 			Ω(err).Should(BeNil())
 		})
 	})
+
+	Context("with a context", func() {
+
+		var httpSource *httpSourceImpl
+
+		BeforeEach(func() {
+			config, err := eva.NewConfiguration(`{
+				"source": {
+					"type":   "http",
+					"server": "localhost",
+					"retries": "5@10000"
+				},
+				"category": "test"
+			}`)
+			Ω(err).Should(BeNil())
+
+			tenant, err := eva.NewTenant("tenant")
+			Ω(err).Should(BeNil())
+
+			source, err := initHttpSource(config, tenant)
+			Ω(err).Should(BeNil())
+			httpSource = source.(*httpSourceImpl)
+		})
+
+		It("should attach the context to the request", func() {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			var reqCtx context.Context
+			httpSource.callClient = func(c httpDoer, r *http.Request) (*http.Response, error) {
+				reqCtx = r.Context()
+				return fakeGoodCaller(edn.EvaEdnMimeType.String())(c, r)
+			}
+
+			res, err := httpSource.call(ctx, "GET", "http://localhost", url.Values{})
+			Ω(err).Should(BeNil())
+			Ω(res).ShouldNot(BeNil())
+			Ω(reqCtx).Should(BeIdenticalTo(ctx))
+		})
+
+		It("should not call the service with a cancelled context", func() {
+			f := fakeRetryCaller(1)
+			httpSource.callClient = f.clientFunc
+
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+
+			res, err := httpSource.call(ctx, "GET", "http://localhost", url.Values{})
+			Ω(err).Should(Equal(context.Canceled))
+			Ω(res).Should(BeNil())
+			Ω(f.callCount).Should(BeEquivalentTo(0))
+		})
+
+		It("should abandon the retries when the context is cancelled", func() {
+			f := fakeRetryCaller(5)
+			httpSource.callClient = f.clientFunc
+
+			ctx, cancel := context.WithCancel(context.Background())
+			time.AfterFunc(50*time.Millisecond, cancel)
+
+			start := time.Now()
+			res, err := httpSource.call(ctx, "GET", "http://localhost", url.Values{})
+			Ω(err).Should(Equal(context.Canceled))
+			Ω(res).Should(BeNil())
+			Ω(f.callCount).Should(BeEquivalentTo(1))
+			Ω(time.Since(start)).Should(BeNumerically("<", 5*time.Second))
+		})
+
+		It("should abandon the retries when the deadline passes", func() {
+			f := fakeRetryCaller(5)
+			httpSource.callClient = f.clientFunc
+
+			ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+			defer cancel()
+
+			res, err := httpSource.call(ctx, "GET", "http://localhost", url.Values{})
+			Ω(err).Should(Equal(context.DeadlineExceeded))
+			Ω(res).Should(BeNil())
+			Ω(f.callCount).Should(BeEquivalentTo(1))
+		})
+
+		It("should return the context error from a cancelled client call", func() {
+			ctx, cancel := context.WithCancel(context.Background())
+
+			calls := 0
+			httpSource.callClient = func(c httpDoer, r *http.Request) (*http.Response, error) {
+				calls++
+				cancel()
+				return nil, &url.Error{Err: r.Context().Err()}
+			}
+
+			res, err := httpSource.call(ctx, "GET", "http://localhost", url.Values{})
+			Ω(err).Should(Equal(context.Canceled))
+			Ω(res).Should(BeNil())
+			Ω(calls).Should(Equal(1))
+		})
+
+		It("should reject a nil context", func() {
+			httpSource.callClient = fakeGoodCaller(edn.EvaEdnMimeType.String())
+
+			res, err := httpSource.call(nil, "GET", "http://localhost", url.Values{})
+			Ω(err).Should(test.HaveMessage(edn.ErrInvalidInput))
+			Ω(res).Should(BeNil())
+		})
+	})
 })
@@ -15,6 +15,8 @@
 package http
 
 import (
+	"context"
+
 	"github.com/Workiva/eva-client-go/edn"
 	"github.com/Workiva/eva-client-go/eva"
 )
@@ -47,6 +49,11 @@ func (source *mockSource) Query(query interface{}, parameters ...interface{}) (r
 	return nil, nil
 }
 
+// QueryContext queries the source for data.
+func (source *mockSource) QueryContext(ctx context.Context, query interface{}, parameters ...interface{}) (result eva.Result, err error) {
+	return nil, nil
+}
+
 // CanLog checks if the logger can log.
 func (source *mockSource) Serializer() (edn.Serializer, error) {
 	return edn.DefaultMimeType, nil
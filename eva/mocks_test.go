@@ -14,19 +14,65 @@
 
 package eva
 
-import "github.com/Workiva/eva-client-go/edn"
+import (
+	"context"
+
+	"github.com/Workiva/eva-client-go/edn"
+)
+
+const mockTxReport = `{
+	:tempids {-1 4398046511105}
+	:eva.client.service/tempids {#db/id [:db.part/user -1] 8796093023233}
+	:db-before #eva.client.service/snapshot-ref {:label "label" :as-of 1}
+	:db-after #eva.client.service/snapshot-ref {:label "label" :as-of 2}
+	:tx-data (#datom [4398046511105 3 "value" 4398046511106 true])}`
 
 type mockResult struct {
+	body    string
+	failure error
 }
 
 // String version of the call.
 func (mock *mockResult) String() (string, bool) {
-	return "test", true
+	return mock.text(), true
+}
+
+// text of the result, which defaults to test.
+func (mock *mockResult) text() string {
+	if len(mock.body) > 0 {
+		return mock.body
+	}
+	return "test"
 }
 
 // Error from the call.
 func (mock *mockResult) Error() (error, bool) {
-	return nil, false
+	return mock.failure, mock.failure != nil
+}
+
+// Element parses the body of the call.
+func (mock *mockResult) Element() (edn.Element, error) {
+	return edn.Parse(mock.text())
+}
+
+// Decode the body of the call.
+func (mock *mockResult) Decode(v interface{}) error {
+	return edn.Unmarshal([]byte(mock.text()), v)
+}
+
+// StatusCode of the call.
+func (mock *mockResult) StatusCode() int {
+	return 200
+}
+
+// ContentType of the body of the call.
+func (mock *mockResult) ContentType() string {
+	return edn.EvaEdnMimeType.String()
+}
+
+// Headers returned with the call.
+func (mock *mockResult) Headers() map[string][]string {
+	return nil
 }
 
 type mockSource struct {
@@ -57,12 +103,17 @@ func (source *mockSource) Query(query interface{}, parameters ...interface{}) (r
 	return nil, nil
 }
 
+// QueryContext queries the source for data.
+func (source *mockSource) QueryContext(ctx context.Context, query interface{}, parameters ...interface{}) (result Result, err error) {
+	return nil, nil
+}
+
 // CanLog checks if the logger can log.
 func (source *mockSource) Serializer() (edn.Serializer, error) {
 	return nil, nil
 }
 
-func mockQuery(_ interface{}, _ ...interface{}) (Result, error) {
+func mockQuery(_ context.Context, _ interface{}, _ ...interface{}) (Result, error) {
 	return &mockResult{}, nil
 }
 
@@ -70,17 +121,17 @@ func makeMockConnChannel(label edn.Serializable, source Source) (c ConnectionCha
 	return NewBaseConnectionChannel(
 		label,
 		source,
-		func(transaction edn.Serializable) (Result, error) {
-			return &mockResult{}, nil
+		func(_ context.Context, transaction edn.Serializable) (Result, error) {
+			return &mockResult{body: mockTxReport}, nil
 		},
 		func(asOf edn.Serializable) (SnapshotChannel, error) {
 			return NewBaseSnapshotChannel(
 				label,
 				source,
-				func(pattern edn.Serializable, ids edn.Serializable, params ...interface{}) (result Result, err error) {
+				func(_ context.Context, pattern edn.Serializable, ids edn.Serializable, params ...interface{}) (result Result, err error) {
 					return &mockResult{}, nil
 				},
-				func(function edn.Serializable, parameters ...interface{}) (result Result, err error) {
+				func(_ context.Context, function edn.Serializable, parameters ...interface{}) (result Result, err error) {
 					return &mockResult{}, nil
 				},
 				asOf)
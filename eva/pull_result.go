@@ -0,0 +1,69 @@
+// Copyright 2018-2019 Workiva Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eva
+
+import (
+	"github.com/Workiva/eva-client-go/edn"
+)
+
+// PullResult is the result of a pull, a single entity map or a collection of them when pulling many ids. Decode the
+// result into structs with Decode, the `edn` field tags name the attributes, e.g. `edn:"book/title"`.
+type PullResult struct {
+	Result
+}
+
+// NewPullResult wraps the result of a pull.
+func NewPullResult(result Result) (pull *PullResult) {
+	if result != nil {
+		pull = &PullResult{Result: result}
+	}
+	return pull
+}
+
+// Get the value at the end of the path through the nested entity maps. The path holds attribute keywords, e.g.
+// Get(":book/author", ":person/name"), and indexes into cardinality many attributes or the entities of a pull many.
+func (result *PullResult) Get(path ...interface{}) (value edn.Element, err error) {
+
+	var elem edn.Element
+	if elem, err = result.Element(); err == nil {
+		value, err = edn.GetIn(elem, path...)
+	}
+
+	return value, err
+}
+
+// Entities returns the pulled entity maps, a single pull has one entity.
+func (result *PullResult) Entities() (entities []edn.CollectionElement, err error) {
+
+	var elem edn.Element
+	if elem, err = result.Element(); err == nil {
+		var coll edn.CollectionElement
+		if coll, err = edn.AsCollection(elem); err == nil {
+			if coll.ElementType() == edn.MapType {
+				entities = append(entities, coll)
+			} else {
+				err = coll.IterateChildren(func(_ edn.Element, value edn.Element) (e error) {
+					var entity edn.CollectionElement
+					if entity, e = asMap(value); e == nil {
+						entities = append(entities, entity)
+					}
+					return e
+				})
+			}
+		}
+	}
+
+	return entities, err
+}
@@ -0,0 +1,139 @@
+// Copyright 2018-2019 Workiva Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eva
+
+import (
+	"context"
+
+	"github.com/Workiva/eva-client-go/edn"
+	"github.com/Workiva/eva-client-go/eva/q"
+	"github.com/Workiva/eva-client-go/test"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Pull result", func() {
+
+	const book = `{:db/id 1
+		:book/title "Dune"
+		:book/year 1965
+		:book/author {:db/id 2 :person/name "Frank Herbert"}
+		:book/tags [:sf :classic]}`
+
+	type person struct {
+		Name string `edn:"person/name"`
+	}
+
+	type bookEntity struct {
+		ID     int64    `edn:"db/id"`
+		Title  string   `edn:"book/title"`
+		Year   int      `edn:"book/year"`
+		Author person   `edn:"book/author"`
+		Tags   []string `edn:"book/tags"`
+	}
+
+	It("should handle a nil result", func() {
+		Ω(NewPullResult(nil)).Should(BeNil())
+	})
+
+	It("should navigate the entity by attribute", func() {
+		result := NewPullResult(&mockResult{body: book})
+
+		value, err := result.Get(":book/title")
+		Ω(err).Should(BeNil())
+		Ω(value.Value()).Should(Equal("Dune"))
+
+		value, err = result.Get(":book/author", ":person/name")
+		Ω(err).Should(BeNil())
+		Ω(value.Value()).Should(Equal("Frank Herbert"))
+
+		value, err = result.Get(":book/tags", 1)
+		Ω(err).Should(BeNil())
+		Ω(value.String()).Should(Equal(":classic"))
+
+		_, err = result.Get(":book/isbn")
+		Ω(err).Should(test.HaveMessage(edn.ErrNoValue))
+
+		_, err = result.Get(":book/title", ":person/name")
+		Ω(err).Should(test.HaveMessage(edn.ErrNoValue))
+	})
+
+	It("should navigate the entities of a pull many", func() {
+		result := NewPullResult(&mockResult{body: "[" + book + ` {:db/id 3 :book/title "Emma"}]`})
+
+		value, err := result.Get(1, ":book/title")
+		Ω(err).Should(BeNil())
+		Ω(value.Value()).Should(Equal("Emma"))
+
+		entities, err := result.Entities()
+		Ω(err).Should(BeNil())
+		Ω(entities).Should(HaveLen(2))
+		Ω(entities[0].Contains(":book/author")).Should(BeTrue())
+	})
+
+	It("should return the single entity", func() {
+		entities, err := NewPullResult(&mockResult{body: book}).Entities()
+		Ω(err).Should(BeNil())
+		Ω(entities).Should(HaveLen(1))
+
+		_, err = NewPullResult(&mockResult{body: "[1 2]"}).Entities()
+		Ω(err).Should(test.HaveMessage(edn.ErrUnexpectedType))
+
+		_, err = NewPullResult(&mockResult{body: "42"}).Entities()
+		Ω(err).Should(test.HaveMessage(edn.ErrUnexpectedType))
+	})
+
+	It("should decode into structs", func() {
+		var entity bookEntity
+		Ω(NewPullResult(&mockResult{body: book}).Decode(&entity)).Should(Succeed())
+		Ω(entity).Should(Equal(bookEntity{
+			ID:     1,
+			Title:  "Dune",
+			Year:   1965,
+			Author: person{Name: "Frank Herbert"},
+			Tags:   []string{":sf", ":classic"},
+		}))
+
+		var entities []bookEntity
+		Ω(NewPullResult(&mockResult{body: "[" + book + "]"}).Decode(&entities)).Should(Succeed())
+		Ω(entities).Should(HaveLen(1))
+		Ω(entities[0].Author.Name).Should(Equal("Frank Herbert"))
+	})
+
+	It("should pull with a pattern builder", func() {
+		var pulled string
+		snap, err := NewBaseSnapshotChannel(
+			edn.NewStringElement("label"),
+			&mockSource{},
+			func(_ context.Context, pattern edn.Serializable, ids edn.Serializable, params ...interface{}) (Result, error) {
+				var e error
+				pulled, e = pattern.Serialize(edn.EvaEdnMimeType)
+				return &mockResult{body: book}, e
+			},
+			func(_ context.Context, function edn.Serializable, parameters ...interface{}) (Result, error) {
+				return nil, nil
+			},
+			nil)
+		Ω(err).Should(BeNil())
+
+		result, err := snap.Pull(q.PullSpec(":book/title", q.Attr(":book/author").Nest(":person/name")), 1)
+		Ω(err).Should(BeNil())
+		Ω(pulled).Should(Equal("[:book/title {:book/author [:person/name]}]"))
+
+		value, err := result.Get(":book/author", ":person/name")
+		Ω(err).Should(BeNil())
+		Ω(value.Value()).Should(Equal("Frank Herbert"))
+	})
+})
@@ -0,0 +1,300 @@
+// Copyright 2018-2019 Workiva Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package q
+
+import (
+	"github.com/Workiva/eva-client-go/edn"
+)
+
+// Clause is a where clause of a query or rule.
+type Clause interface {
+	Term
+
+	// bound returns the variables the clause binds.
+	bound() []string
+}
+
+// pattern is a data pattern, e.g. [?b :book/title ?title].
+type pattern struct {
+	terms []interface{}
+}
+
+// Pattern creates a data pattern clause, e.g. Pattern("?b", ":book/title", "?title"). Start the pattern with a
+// source, e.g. "$books", to match against a source other than the default.
+func Pattern(terms ...interface{}) Clause {
+	return &pattern{terms: terms}
+}
+
+// Element of this pattern.
+func (pat *pattern) Element() (elem edn.Element, err error) {
+
+	if len(pat.terms) == 0 {
+		err = edn.MakeError(ErrInvalidQuery, "empty pattern")
+	} else {
+		elem, err = vector(pat.terms...)
+	}
+
+	return elem, err
+}
+
+// bound returns the variables bound by the pattern.
+func (pat *pattern) bound() []string {
+	return variablesOf(pat.terms...)
+}
+
+// predicate is a predicate expression, e.g. [(< ?year 2000)].
+type predicate struct {
+	fn   string
+	args []interface{}
+}
+
+// Pred creates a predicate clause, e.g. Pred("<", "?year", 2000) is [(< ?year 2000)].
+func Pred(fn string, args ...interface{}) Clause {
+	return &predicate{fn: fn, args: args}
+}
+
+// Element of this predicate.
+func (pred *predicate) Element() (elem edn.Element, err error) {
+
+	var expr edn.Element
+	if expr, err = list(pred.fn, pred.args...); err == nil {
+		elem, err = vector(expr)
+	}
+
+	return elem, err
+}
+
+// bound returns nothing, predicates do not bind variables.
+func (pred *predicate) bound() []string {
+	return nil
+}
+
+// FnClause is a function expression, e.g. [(str ?first " " ?last) ?name].
+type FnClause struct {
+	fn      string
+	args    []interface{}
+	binding interface{}
+}
+
+// Fn creates a function expression clause, the result must be bound with Bind.
+func Fn(fn string, args ...interface{}) *FnClause {
+	return &FnClause{fn: fn, args: args}
+}
+
+// Bind the result of the function to a variable, e.g. "?name", or to a binding, e.g. BindColl("?x").
+func (clause *FnClause) Bind(binding interface{}) *FnClause {
+	clause.binding = binding
+	return clause
+}
+
+// Element of this function expression.
+func (clause *FnClause) Element() (elem edn.Element, err error) {
+
+	switch b := clause.binding.(type) {
+	case nil:
+		err = edn.MakeErrorWithFormat(ErrInvalidQuery, "the result of %s is not bound", clause.fn)
+	case string:
+		err = checkVariables(b)
+	}
+
+	if err == nil {
+		var expr edn.Element
+		if expr, err = list(clause.fn, clause.args...); err == nil {
+			elem, err = vector(expr, clause.binding)
+		}
+	}
+
+	return elem, err
+}
+
+// bound returns the variables bound by the result of the function.
+func (clause *FnClause) bound() []string {
+	return variablesOf(clause.binding)
+}
+
+// ruleCall invokes a rule, e.g. (ancestor ?a ?b).
+type ruleCall struct {
+	name string
+	args []interface{}
+}
+
+// Rule creates a clause that invokes the rule, e.g. Rule("ancestor", "?a", "?b"). The rules are passed to the query
+// as the % input, see Rules.
+func Rule(name string, args ...interface{}) Clause {
+	return &ruleCall{name: name, args: args}
+}
+
+// Element of this rule invocation.
+func (call *ruleCall) Element() (edn.Element, error) {
+	return list(call.name, call.args...)
+}
+
+// bound returns the variables bound by the rule invocation.
+func (call *ruleCall) bound() []string {
+	return variablesOf(call.args...)
+}
+
+// combination combines clauses, e.g. (or ...), (not-join [?x] ...).
+type combination struct {
+	op      string
+	join    []string
+	joined  bool
+	binds   bool
+	clauses []Clause
+}
+
+// Not creates a clause that removes the matches of the clauses, (not ...).
+func Not(clauses ...Clause) Clause {
+	return &combination{op: "not", clauses: clauses}
+}
+
+// NotJoin creates a not clause that only unifies the join variables, (not-join [?x] ...).
+func NotJoin(join []string, clauses ...Clause) Clause {
+	return &combination{op: "not-join", join: join, joined: true, clauses: clauses}
+}
+
+// Or creates a clause that matches any of the clauses, (or ...). Use And to group the clauses of a branch.
+func Or(clauses ...Clause) Clause {
+	return &combination{op: "or", binds: true, clauses: clauses}
+}
+
+// OrJoin creates an or clause that only unifies the join variables, (or-join [?x] ...).
+func OrJoin(join []string, clauses ...Clause) Clause {
+	return &combination{op: "or-join", join: join, joined: true, binds: true, clauses: clauses}
+}
+
+// And groups the clauses of a branch of an or clause, (and ...).
+func And(clauses ...Clause) Clause {
+	return &combination{op: "and", binds: true, clauses: clauses}
+}
+
+// Element of this combination.
+func (comb *combination) Element() (elem edn.Element, err error) {
+
+	if len(comb.clauses) == 0 {
+		err = edn.MakeErrorWithFormat(ErrInvalidQuery, "empty %s clause", comb.op)
+	}
+
+	var args []interface{}
+	if err == nil && comb.joined {
+		if len(comb.join) == 0 {
+			err = edn.MakeErrorWithFormat(ErrInvalidQuery, "no join variables in %s clause", comb.op)
+		} else if err = checkVariables(comb.join...); err == nil {
+			var join edn.Element
+			if join, err = vector(variableStrings(comb.join)...); err == nil {
+				args = append(args, join)
+			}
+		}
+	}
+
+	if err == nil {
+		for _, clause := range comb.clauses {
+			args = append(args, clause)
+		}
+		elem, err = list(comb.op, args...)
+	}
+
+	return elem, err
+}
+
+// bound returns the variables bound by the combination, not clauses bind nothing.
+func (comb *combination) bound() (vars []string) {
+
+	switch {
+	case !comb.binds:
+	case comb.joined:
+		vars = comb.join
+	default:
+		for _, clause := range comb.clauses {
+			vars = append(vars, clause.bound()...)
+		}
+	}
+
+	return vars
+}
+
+// RuleDefinition defines a rule, see Rules.
+type RuleDefinition struct {
+	name    string
+	params  []string
+	clauses []Clause
+}
+
+// DefRule starts the definition of a rule with the name and parameters, e.g. DefRule("ancestor", "?a", "?b").
+func DefRule(name string, params ...string) *RuleDefinition {
+	return &RuleDefinition{name: name, params: params}
+}
+
+// Where adds the clauses to the body of the rule.
+func (rule *RuleDefinition) Where(clauses ...Clause) *RuleDefinition {
+	rule.clauses = append(rule.clauses, clauses...)
+	return rule
+}
+
+// Element of this rule definition, [(name params...) clauses...].
+func (rule *RuleDefinition) Element() (elem edn.Element, err error) {
+
+	if len(rule.clauses) == 0 {
+		err = edn.MakeErrorWithFormat(ErrInvalidQuery, "rule %s has no clauses", rule.name)
+	} else {
+		err = checkVariables(rule.params...)
+	}
+
+	if err == nil {
+		var head edn.Element
+		if head, err = list(rule.name, variableStrings(rule.params)...); err == nil {
+			values := []interface{}{head}
+			for _, clause := range rule.clauses {
+				values = append(values, clause)
+			}
+			elem, err = vector(values...)
+		}
+	}
+
+	return elem, err
+}
+
+// RuleSet is the set of rules passed to a query as the % input.
+type RuleSet struct {
+	rules []*RuleDefinition
+}
+
+// Rules creates the rule set to pass as the % input of the query, e.g.
+//
+//	source.Query(query, q.Rules(q.DefRule("ancestor", "?a", "?b").Where(...)))
+func Rules(rules ...*RuleDefinition) *RuleSet {
+	return &RuleSet{rules: rules}
+}
+
+// Element of this rule set.
+func (set *RuleSet) Element() (edn.Element, error) {
+
+	values := make([]interface{}, len(set.rules))
+	for i, rule := range set.rules {
+		values[i] = rule
+	}
+
+	return vector(values...)
+}
+
+// Serialize the rule set so it can be passed to the query.
+func (set *RuleSet) Serialize(serializer edn.Serializer) (string, error) {
+	return serialize(set, serializer)
+}
+
+// String representation of the rule set.
+func (set *RuleSet) String() string {
+	return stringOf(set)
+}
@@ -0,0 +1,146 @@
+// Copyright 2018-2019 Workiva Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package q_test
+
+import (
+	"github.com/Workiva/eva-client-go/edn"
+	"github.com/Workiva/eva-client-go/eva/q"
+	"github.com/Workiva/eva-client-go/test"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Query clauses", func() {
+
+	serialized := func(term q.Term) string {
+		elem, err := term.Element()
+		Ω(err).Should(BeNil())
+
+		out, err := elem.Serialize(edn.EvaEdnMimeType)
+		Ω(err).Should(BeNil())
+		return out
+	}
+
+	bound := func(clause q.Clause, vars ...string) {
+		for _, v := range vars {
+			Ω(q.Find(v).Where(clause).Validate()).Should(Succeed(), v)
+		}
+	}
+
+	unbound := func(clause q.Clause, vars ...string) {
+		for _, v := range vars {
+			Ω(q.Find(v).Where(clause).Validate()).Should(test.HaveMessage(q.ErrUnboundVariable), v)
+		}
+	}
+
+	It("should create the patterns", func() {
+		clause := q.Pattern("?b", ":book/title", "?title")
+		Ω(serialized(clause)).Should(Equal("[?b :book/title ?title]"))
+		bound(clause, "?b", "?title")
+
+		Ω(serialized(q.Pattern("$books", "?b", ":book/year", 1999))).Should(Equal("[$books ?b :book/year 1999]"))
+		Ω(serialized(q.Pattern("?b", ":book/title", "_"))).Should(Equal("[?b :book/title _]"))
+
+		_, err := q.Pattern().Element()
+		Ω(err).Should(test.HaveMessage(q.ErrInvalidQuery))
+	})
+
+	It("should create the predicates", func() {
+		clause := q.Pred("<", "?year", 2000)
+		Ω(serialized(clause)).Should(Equal("[(< ?year 2000)]"))
+		unbound(clause, "?year")
+
+		Ω(serialized(q.Pred("clojure.string/starts-with?", "?title", "The"))).Should(Equal(`[(clojure.string/starts-with? ?title "The")]`))
+	})
+
+	It("should create the function expressions", func() {
+		clause := q.Fn("str", "?first", " ", "?last").Bind("?name")
+		Ω(serialized(clause)).Should(Equal(`[(str ?first " " ?last) ?name]`))
+		bound(clause, "?name")
+		unbound(clause, "?first")
+
+		clause = q.Fn("ground", edn.NewIntegerElement(1)).Bind(q.BindColl("?x"))
+		Ω(serialized(clause)).Should(Equal(`[(ground 1) [?x ...]]`))
+		bound(clause, "?x")
+
+		_, err := q.Fn("str", "?a").Element()
+		Ω(err).Should(test.HaveMessage(q.ErrInvalidQuery))
+
+		_, err = q.Fn("str", "?a").Bind("name").Element()
+		Ω(err).Should(test.HaveMessage(q.ErrInvalidQuery))
+	})
+
+	It("should create the rule invocations", func() {
+		clause := q.Rule("ancestor", "?a", "?b")
+		Ω(serialized(clause)).Should(Equal("(ancestor ?a ?b)"))
+		bound(clause, "?a", "?b")
+	})
+
+	It("should create the not clauses", func() {
+		clause := q.Not(q.Pattern("?b", ":book/author", "?a"))
+		Ω(serialized(clause)).Should(Equal("(not [?b :book/author ?a])"))
+		unbound(clause, "?a", "?b")
+
+		clause = q.NotJoin([]string{"?b"}, q.Pattern("?b", ":book/author", "?a"))
+		Ω(serialized(clause)).Should(Equal("(not-join [?b] [?b :book/author ?a])"))
+		unbound(clause, "?a", "?b")
+	})
+
+	It("should create the or clauses", func() {
+		clause := q.Or(q.Pattern("?b", ":book/year", 1999), q.And(q.Pattern("?b", ":book/genre", "?g"), q.Pred("=", "?g", "sf")))
+		Ω(serialized(clause)).Should(Equal(`(or [?b :book/year 1999] (and [?b :book/genre ?g] [(= ?g "sf")]))`))
+		bound(clause, "?b", "?g")
+
+		clause = q.OrJoin([]string{"?b"}, q.Pattern("?b", ":book/author", "?a"), q.Pattern("?b", ":book/editor", "?a"))
+		Ω(serialized(clause)).Should(Equal("(or-join [?b] [?b :book/author ?a] [?b :book/editor ?a])"))
+		bound(clause, "?b")
+		unbound(clause, "?a")
+	})
+
+	It("should fail on invalid combinations", func() {
+		_, err := q.Or().Element()
+		Ω(err).Should(test.HaveMessage(q.ErrInvalidQuery))
+
+		_, err = q.OrJoin(nil, q.Pattern("?b", ":a", 1)).Element()
+		Ω(err).Should(test.HaveMessage(q.ErrInvalidQuery))
+
+		_, err = q.NotJoin([]string{"b"}, q.Pattern("?b", ":a", 1)).Element()
+		Ω(err).Should(test.HaveMessage(q.ErrInvalidQuery))
+
+		_, err = q.Not(q.Pattern()).Element()
+		Ω(err).Should(test.HaveMessage(q.ErrInvalidQuery))
+	})
+
+	It("should create the rules", func() {
+		rules := q.Rules(
+			q.DefRule("ancestor", "?a", "?b").Where(q.Pattern("?a", ":parent", "?b")),
+			q.DefRule("ancestor", "?a", "?b").Where(q.Pattern("?a", ":parent", "?c"), q.Rule("ancestor", "?c", "?b")))
+
+		expected := "[[(ancestor ?a ?b) [?a :parent ?b]] [(ancestor ?a ?b) [?a :parent ?c] (ancestor ?c ?b)]]"
+		Ω(serialized(rules)).Should(Equal(expected))
+		Ω(rules.String()).Should(Equal(expected))
+
+		out, err := rules.Serialize(edn.EvaEdnMimeType)
+		Ω(err).Should(BeNil())
+		Ω(out).Should(Equal(expected))
+
+		_, err = q.Rules(q.DefRule("empty", "?a")).Element()
+		Ω(err).Should(test.HaveMessage(q.ErrInvalidQuery))
+
+		_, err = q.Rules(q.DefRule("bad", "a").Where(q.Pattern("?a", ":b", 1))).Element()
+		Ω(err).Should(test.HaveMessage(q.ErrInvalidQuery))
+		Ω(q.Rules(q.DefRule("empty", "?a")).String()).Should(BeEmpty())
+	})
+})
@@ -0,0 +1,178 @@
+// Copyright 2018-2019 Workiva Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package q
+
+import (
+	"strings"
+
+	"github.com/Workiva/eva-client-go/edn"
+)
+
+const (
+
+	// Wildcard pulls all the attributes of the entity.
+	Wildcard = "*"
+
+	// reverseSeparator marks a reverse reference, e.g. :book/_author
+	reverseSeparator = "/_"
+
+	// reversePrefix marks a reverse reference of an attribute without a namespace, e.g. :_author
+	reversePrefix = "_"
+)
+
+// PullPattern is a pull pattern, e.g. [:book/title {:book/author [:person/name]}].
+type PullPattern struct {
+	specs []interface{}
+}
+
+// PullSpec creates a pull pattern, the specs are the Wildcard, attribute keywords, e.g. ":book/title", or attributes
+// built with Attr and ReverseAttr. The pattern can be passed to eva.SnapshotChannel.Pull or used with Pull in a
+// query.
+func PullSpec(specs ...interface{}) *PullPattern {
+	return &PullPattern{specs: specs}
+}
+
+// Element of this pull pattern.
+func (pattern *PullPattern) Element() (elem edn.Element, err error) {
+
+	if len(pattern.specs) == 0 {
+		err = edn.MakeError(ErrInvalidQuery, "empty pull pattern")
+	}
+
+	for _, spec := range pattern.specs {
+		if err != nil {
+			break
+		}
+
+		switch v := spec.(type) {
+		case string:
+			if v != Wildcard && !strings.HasPrefix(v, edn.KeywordPrefix) {
+				err = edn.MakeErrorWithFormat(ErrInvalidQuery, "expected an attribute or *, got: %s", v)
+			}
+		case *PullAttr:
+		default:
+			err = edn.MakeErrorWithFormat(ErrInvalidQuery, "unsupported pull spec: %v", v)
+		}
+	}
+
+	if err == nil {
+		values := make([]interface{}, len(pattern.specs))
+		for i, spec := range pattern.specs {
+			if spec == Wildcard {
+				spec = Sym(Wildcard)
+			}
+			values[i] = spec
+		}
+		elem, err = vector(values...)
+	}
+
+	return elem, err
+}
+
+// Serialize the pull pattern.
+func (pattern *PullPattern) Serialize(serializer edn.Serializer) (string, error) {
+	return serialize(pattern, serializer)
+}
+
+// String of this pull pattern as edn, or an empty string if the pattern is invalid.
+func (pattern *PullPattern) String() string {
+	return stringOf(pattern)
+}
+
+// PullAttr is an attribute of a pull pattern with options and a nested pattern.
+type PullAttr struct {
+	attr      string
+	options   []interface{}
+	nested    *PullPattern
+	hasNested bool
+}
+
+// Attr creates the attribute of a pull pattern, e.g. Attr(":book/author").
+func Attr(attr string) *PullAttr {
+	return &PullAttr{attr: attr}
+}
+
+// ReverseAttr creates the reverse reference of the attribute, e.g. ReverseAttr(":book/author") pulls
+// :book/_author, the entities that reference the pulled entity. An attribute without a namespace, e.g. :author, is
+// reversed as :_author.
+func ReverseAttr(attr string) *PullAttr {
+	if i := strings.LastIndex(attr, edn.SymbolSeparator); i >= 0 {
+		if !strings.HasPrefix(attr[i:], reverseSeparator) {
+			attr = attr[:i] + reverseSeparator + attr[i+1:]
+		}
+	} else if name := strings.TrimPrefix(attr, edn.KeywordPrefix); !strings.HasPrefix(name, reversePrefix) {
+		attr = edn.KeywordPrefix + reversePrefix + name
+	}
+	return Attr(attr)
+}
+
+// As renames the attribute in the result, e.g. As(":author") or As("author").
+func (attr *PullAttr) As(name interface{}) *PullAttr {
+	attr.options = append(attr.options, ":as", name)
+	return attr
+}
+
+// Limit the number of values of a cardinality many attribute, the default limit is 1000.
+func (attr *PullAttr) Limit(limit int) *PullAttr {
+	attr.options = append(attr.options, ":limit", limit)
+	return attr
+}
+
+// NoLimit returns all the values of a cardinality many attribute.
+func (attr *PullAttr) NoLimit() *PullAttr {
+	attr.options = append(attr.options, ":limit", edn.NewNilElement())
+	return attr
+}
+
+// Default value of the attribute when the entity does not have it.
+func (attr *PullAttr) Default(value interface{}) *PullAttr {
+	attr.options = append(attr.options, ":default", value)
+	return attr
+}
+
+// Nest pulls the specs from the referenced entities, e.g. {:book/author [:person/name]}.
+func (attr *PullAttr) Nest(specs ...interface{}) *PullAttr {
+	attr.nested = PullSpec(specs...)
+	attr.hasNested = true
+	return attr
+}
+
+// Element of this attribute.
+func (attr *PullAttr) Element() (elem edn.Element, err error) {
+
+	if !strings.HasPrefix(attr.attr, edn.KeywordPrefix) {
+		err = edn.MakeErrorWithFormat(ErrInvalidQuery, "expected an attribute, got: %s", attr.attr)
+	}
+
+	if err == nil {
+		if len(attr.options) > 0 {
+			elem, err = vector(append([]interface{}{attr.attr}, attr.options...)...)
+		} else {
+			elem, err = toElement(attr.attr)
+		}
+	}
+
+	if err == nil && attr.hasNested {
+		var nested edn.Element
+		if nested, err = attr.nested.Element(); err == nil {
+			var pair edn.Pair
+			if pair, err = edn.NewPair(elem, nested); err == nil {
+				elem, err = edn.NewMap(pair)
+			}
+		}
+	}
+
+	return elem, err
+}
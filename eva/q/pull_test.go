@@ -0,0 +1,94 @@
+// Copyright 2018-2019 Workiva Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package q_test
+
+import (
+	"github.com/Workiva/eva-client-go/edn"
+	"github.com/Workiva/eva-client-go/eva/q"
+	"github.com/Workiva/eva-client-go/test"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Pull patterns", func() {
+
+	It("should build the wildcard and attributes", func() {
+		Ω(q.PullSpec(q.Wildcard).String()).Should(Equal("[*]"))
+		Ω(q.PullSpec(":book/title", ":book/year").String()).Should(Equal("[:book/title :book/year]"))
+		Ω(q.PullSpec("*", q.Attr(":book/title")).String()).Should(Equal("[* :book/title]"))
+	})
+
+	It("should build the nested references", func() {
+		pattern := q.PullSpec(":book/title", q.Attr(":book/author").Nest(":person/name", q.Attr(":person/born").Nest("*")))
+		Ω(pattern.String()).Should(Equal("[:book/title {:book/author [:person/name {:person/born [*]}]}]"))
+	})
+
+	It("should build the reverse references", func() {
+		Ω(q.PullSpec(q.ReverseAttr(":book/author")).String()).Should(Equal("[:book/_author]"))
+		Ω(q.PullSpec(q.ReverseAttr(":book/_author")).String()).Should(Equal("[:book/_author]"))
+		Ω(q.PullSpec(q.ReverseAttr(":my.ns/author")).String()).Should(Equal("[:my.ns/_author]"))
+		Ω(q.PullSpec(q.ReverseAttr(":author")).String()).Should(Equal("[:_author]"))
+		Ω(q.PullSpec(q.ReverseAttr(":_author")).String()).Should(Equal("[:_author]"))
+		Ω(q.PullSpec(q.ReverseAttr("author")).String()).Should(Equal("[:_author]"))
+		Ω(q.PullSpec(q.ReverseAttr(":book/author").Nest(":book/title")).String()).Should(Equal("[{:book/_author [:book/title]}]"))
+	})
+
+	It("should build the options", func() {
+		Ω(q.PullSpec(q.Attr(":book/tags").Limit(5)).String()).Should(Equal("[[:book/tags :limit 5]]"))
+		Ω(q.PullSpec(q.Attr(":book/tags").NoLimit()).String()).Should(Equal("[[:book/tags :limit nil]]"))
+		Ω(q.PullSpec(q.Attr(":book/year").Default(0)).String()).Should(Equal("[[:book/year :default 0]]"))
+		Ω(q.PullSpec(q.Attr(":book/title").As(":title")).String()).Should(Equal("[[:book/title :as :title]]"))
+		Ω(q.PullSpec(q.Attr(":book/title").As("Title")).String()).Should(Equal(`[[:book/title :as "Title"]]`))
+		Ω(q.PullSpec(q.Attr(":book/title").Default("none").As(":title")).String()).Should(Equal(`[[:book/title :default "none" :as :title]]`))
+
+		pattern := q.PullSpec(q.ReverseAttr(":book/author").Limit(10).As(":books").Nest(":book/title"))
+		Ω(pattern.String()).Should(Equal("[{[:book/_author :limit 10 :as :books] [:book/title]}]"))
+	})
+
+	It("should be parsable", func() {
+		pattern := q.PullSpec("*", q.Attr(":book/author").Limit(2).Nest(":person/name"))
+
+		elem, err := pattern.Element()
+		Ω(err).Should(BeNil())
+
+		parsed, err := edn.Parse(pattern.String())
+		Ω(err).Should(BeNil())
+		Ω(parsed.Equals(elem)).Should(BeTrue())
+
+		out, err := pattern.Serialize(edn.EvaEdnMimeType)
+		Ω(err).Should(BeNil())
+		Ω(out).Should(Equal(pattern.String()))
+	})
+
+	It("should be used in queries", func() {
+		query := q.Find(q.Pull("?b", q.PullSpec(":book/title"))).Where(q.Pattern("?b", ":book/year", 1965))
+		Ω(query.String()).Should(Equal("[:find (pull ?b [:book/title]) :where [?b :book/year 1965]]"))
+	})
+
+	It("should fail on invalid patterns", func() {
+		for _, pattern := range []*q.PullPattern{
+			q.PullSpec(),
+			q.PullSpec("title"),
+			q.PullSpec(42),
+			q.PullSpec(q.Attr("book/title")),
+			q.PullSpec(q.Attr(":book/author").Nest()),
+			q.PullSpec(q.Attr(":book/author").Nest("author")),
+		} {
+			_, err := pattern.Element()
+			Ω(err).Should(test.HaveMessage(q.ErrInvalidQuery))
+			Ω(pattern.String()).Should(BeEmpty())
+		}
+	})
+})
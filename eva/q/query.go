@@ -0,0 +1,220 @@
+// Copyright 2018-2019 Workiva Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package q
+
+import (
+	"github.com/Workiva/eva-client-go/edn"
+)
+
+const (
+
+	// ErrInvalidQuery defines the error for a query that can not be built.
+	ErrInvalidQuery = edn.ErrorMessage("Invalid query")
+
+	// ErrUnboundVariable defines the error for a find variable that is not bound by the query.
+	ErrUnboundVariable = edn.ErrorMessage("Unbound query variable")
+)
+
+// findShape is the shape of the find spec.
+type findShape int
+
+const (
+	relationFind findShape = iota
+	scalarFind
+	collectionFind
+	tupleFind
+)
+
+// Query is a datalog query. Queries are built with Find, FindScalar, FindColl or FindTuple and the clauses added with
+// In, With and Where. The query is checked when the element is created, every variable in the find spec and :with
+// must be bound by an input or a where clause.
+type Query struct {
+	shape findShape
+	find  []interface{}
+	in    []interface{}
+	with  []string
+	where []Clause
+}
+
+// Find creates a query that returns a relation, e.g. Find("?title", q.Count("?b")) is [:find ?title (count ?b)].
+// The find elements are variables, aggregates or pull expressions.
+func Find(elems ...interface{}) *Query {
+	return &Query{shape: relationFind, find: elems}
+}
+
+// FindScalar creates a query that returns a single value, [:find ?title .]
+func FindScalar(elem interface{}) *Query {
+	return &Query{shape: scalarFind, find: []interface{}{elem}}
+}
+
+// FindColl creates a query that returns a collection, [:find [?title ...]]
+func FindColl(elem interface{}) *Query {
+	return &Query{shape: collectionFind, find: []interface{}{elem}}
+}
+
+// FindTuple creates a query that returns a single tuple, [:find [?title ?year]]
+func FindTuple(elems ...interface{}) *Query {
+	return &Query{shape: tupleFind, find: elems}
+}
+
+// In adds the inputs of the query. Inputs are sources, e.g. "$", the rules "%", variables, e.g. "?year", or bindings
+// created with BindColl, BindTuple or BindRel.
+func (query *Query) In(inputs ...interface{}) *Query {
+	query.in = append(query.in, inputs...)
+	return query
+}
+
+// With adds the variables to keep duplicates for when aggregating.
+func (query *Query) With(vars ...string) *Query {
+	query.with = append(query.with, vars...)
+	return query
+}
+
+// Where adds the clauses of the query.
+func (query *Query) Where(clauses ...Clause) *Query {
+	query.where = append(query.where, clauses...)
+	return query
+}
+
+// Validate checks the query, every variable in the find spec and :with must be bound by an input or a where clause.
+func (query *Query) Validate() (err error) {
+
+	if len(query.find) == 0 {
+		err = edn.MakeError(ErrInvalidQuery, "no find elements")
+	}
+
+	for _, elem := range query.find {
+		if err != nil {
+			break
+		}
+
+		switch v := elem.(type) {
+		case string:
+			if !IsVariable(v) {
+				err = edn.MakeErrorWithFormat(ErrInvalidQuery, "expected a variable to find, got: %s", v)
+			}
+		case variableTerm:
+		default:
+			err = edn.MakeErrorWithFormat(ErrInvalidQuery, "unsupported find element: %v", v)
+		}
+	}
+
+	if err == nil {
+		err = checkVariables(query.with...)
+	}
+
+	if err == nil {
+		bound := map[string]bool{}
+		for _, v := range variablesOf(query.in...) {
+			bound[v] = true
+		}
+
+		for _, clause := range query.where {
+			for _, v := range clause.bound() {
+				bound[v] = true
+			}
+		}
+
+		for _, v := range append(variablesOf(query.find...), query.with...) {
+			if !bound[v] {
+				err = edn.MakeError(ErrUnboundVariable, v)
+				break
+			}
+		}
+	}
+
+	return err
+}
+
+// Element of this query, the query is validated first.
+func (query *Query) Element() (elem edn.Element, err error) {
+
+	var values []interface{}
+	if err = query.Validate(); err == nil {
+		values, err = query.findSpec()
+	}
+
+	if err == nil {
+		if len(query.in) > 0 {
+			values = append(append(values, ":in"), query.in...)
+		}
+
+		if len(query.with) > 0 {
+			values = append(append(values, ":with"), variableStrings(query.with)...)
+		}
+
+		if len(query.where) > 0 {
+			values = append(values, ":where")
+			for _, clause := range query.where {
+				values = append(values, clause)
+			}
+		}
+
+		elem, err = vector(values...)
+	}
+
+	return elem, err
+}
+
+// findSpec returns the :find part of the query.
+func (query *Query) findSpec() (values []interface{}, err error) {
+
+	values = []interface{}{":find"}
+	switch query.shape {
+	case scalarFind:
+		values = append(values, query.find[0], Sym(scalarSymbol))
+	case collectionFind:
+		var coll edn.Element
+		if coll, err = vector(query.find[0], Sym(collectionSymbol)); err == nil {
+			values = append(values, coll)
+		}
+	case tupleFind:
+		var tuple edn.Element
+		if tuple, err = vector(query.find...); err == nil {
+			values = append(values, tuple)
+		}
+	default:
+		values = append(values, query.find...)
+	}
+
+	return values, err
+}
+
+// Serialize the query, so it can be passed to eva.Source.Query.
+func (query *Query) Serialize(serializer edn.Serializer) (string, error) {
+	return serialize(query, serializer)
+}
+
+// String of this query as edn, or an empty string if the query is invalid.
+func (query *Query) String() string {
+	return stringOf(query)
+}
+
+// serialize the term.
+func serialize(term Term, serializer edn.Serializer) (out string, err error) {
+
+	var elem edn.Element
+	if elem, err = term.Element(); err == nil {
+		out, err = elem.Serialize(serializer)
+	}
+
+	return out, err
+}
+
+// stringOf returns the edn string of the term, or an empty string if the term is invalid.
+func stringOf(term Term) string {
+	out, _ := serialize(term, edn.EvaEdnMimeType)
+	return out
+}
@@ -0,0 +1,146 @@
+// Copyright 2018-2019 Workiva Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package q_test
+
+import (
+	"github.com/Workiva/eva-client-go/edn"
+	"github.com/Workiva/eva-client-go/eva/q"
+	"github.com/Workiva/eva-client-go/test"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Query builder", func() {
+
+	Context("with valid queries", func() {
+
+		It("should build the query", func() {
+			query := q.Find("?title").In("$", "?year").Where(
+				q.Pattern("?b", ":book/title", "?title"),
+				q.Pattern("?b", ":book/year", "?year"))
+
+			Ω(query.Validate()).Should(Succeed())
+			Ω(query.String()).Should(Equal("[:find ?title :in $ ?year :where [?b :book/title ?title] [?b :book/year ?year]]"))
+
+			elem, err := query.Element()
+			Ω(err).Should(BeNil())
+			Ω(elem.ElementType()).Should(Equal(edn.VectorType))
+
+			parsed, err := edn.Parse(query.String())
+			Ω(err).Should(BeNil())
+			Ω(parsed.Equals(elem)).Should(BeTrue())
+		})
+
+		It("should build the find specs", func() {
+			where := q.Pattern("?b", ":book/title", "?title")
+
+			Ω(q.FindScalar("?title").Where(where).String()).Should(Equal("[:find ?title . :where [?b :book/title ?title]]"))
+			Ω(q.FindColl("?title").Where(where).String()).Should(Equal("[:find [?title ...] :where [?b :book/title ?title]]"))
+			Ω(q.FindTuple("?b", "?title").Where(where).String()).Should(Equal("[:find [?b ?title] :where [?b :book/title ?title]]"))
+			Ω(q.Find("?b", "?title").Where(where).String()).Should(Equal("[:find ?b ?title :where [?b :book/title ?title]]"))
+		})
+
+		It("should build aggregates with :with", func() {
+			query := q.Find("?author", q.Count("?b")).With("?title").Where(
+				q.Pattern("?b", ":book/author", "?author"),
+				q.Pattern("?b", ":book/title", "?title"))
+
+			Ω(query.String()).Should(Equal("[:find ?author (count ?b) :with ?title :where [?b :book/author ?author] [?b :book/title ?title]]"))
+		})
+
+		It("should build pulls", func() {
+			query := q.Find(q.Pull("?b", "[*]")).In("$", "?title").Where(q.Pattern("?b", ":book/title", "?title"))
+			Ω(query.String()).Should(Equal("[:find (pull ?b [*]) :in $ ?title :where [?b :book/title ?title]]"))
+		})
+
+		It("should bind the variables with the inputs", func() {
+			query := q.Find("?title").In("$", q.BindColl("?title"))
+			Ω(query.String()).Should(Equal("[:find ?title :in $ [?title ...]]"))
+
+			query = q.Find("?a", "?b").In(q.BindRel("?a", "?b"))
+			Ω(query.String()).Should(Equal("[:find ?a ?b :in [[?a ?b]]]"))
+		})
+
+		It("should bind the variables with the clauses", func() {
+			query := q.Find("?name", "?a").In("$", "%").Where(
+				q.Fn("str", "?first", "?last").Bind("?name"),
+				q.Rule("ancestor", "?a", "?b"),
+				q.OrJoin([]string{"?first"}, q.Pattern("?first", ":x", 1)),
+				q.Or(q.Pattern("?last", ":y", 2)),
+				q.Pred("<", "?a", 3),
+				q.Not(q.Pattern("?a", ":z", 3)))
+
+			Ω(query.Validate()).Should(Succeed())
+			Ω(query.String()).Should(Equal(`[:find ?name ?a :in $ % :where [(str ?first ?last) ?name] (ancestor ?a ?b) ` +
+				`(or-join [?first] [?first :x 1]) (or [?last :y 2]) [(< ?a 3)] (not [?a :z 3])]`))
+		})
+
+		It("should serialize the query for the source", func() {
+			query := q.Find("?b").Where(q.Pattern("?b", ":book/title", "Dune"))
+
+			var serializable edn.Serializable = query
+			out, err := serializable.Serialize(edn.EvaEdnMimeType)
+			Ω(err).Should(BeNil())
+			Ω(out).Should(Equal(`[:find ?b :where [?b :book/title "Dune"]]`))
+		})
+	})
+
+	Context("with invalid queries", func() {
+
+		It("should fail without find elements", func() {
+			query := q.Find().Where(q.Pattern("?b", ":book/title", "?title"))
+			Ω(query.Validate()).Should(test.HaveMessage(q.ErrInvalidQuery))
+			Ω(query.String()).Should(BeEmpty())
+
+			_, err := query.Serialize(edn.EvaEdnMimeType)
+			Ω(err).Should(test.HaveMessage(q.ErrInvalidQuery))
+		})
+
+		It("should fail on unsupported find elements", func() {
+			Ω(q.Find("title").Validate()).Should(test.HaveMessage(q.ErrInvalidQuery))
+			Ω(q.Find(42).Validate()).Should(test.HaveMessage(q.ErrInvalidQuery))
+			Ω(q.Find(q.Pattern("?b")).Validate()).Should(test.HaveMessage(q.ErrInvalidQuery))
+		})
+
+		It("should fail on unbound find variables", func() {
+			query := q.Find("?title", "?year").Where(q.Pattern("?b", ":book/title", "?title"))
+			err := query.Validate()
+			Ω(err).Should(test.HaveMessage(q.ErrUnboundVariable))
+			Ω(err.Error()).Should(ContainSubstring("?year"))
+
+			_, err = query.Element()
+			Ω(err).Should(test.HaveMessage(q.ErrUnboundVariable))
+		})
+
+		It("should fail on variables only used by predicates or not clauses", func() {
+			Ω(q.Find("?year").Where(q.Pred("<", "?year", 2000)).Validate()).Should(test.HaveMessage(q.ErrUnboundVariable))
+			Ω(q.Find("?b").Where(q.Not(q.Pattern("?b", ":a", 1))).Validate()).Should(test.HaveMessage(q.ErrUnboundVariable))
+			Ω(q.Find("?b").Where(q.OrJoin([]string{"?a"}, q.Pattern("?b", ":a", "?a"))).Validate()).Should(test.HaveMessage(q.ErrUnboundVariable))
+		})
+
+		It("should fail on unbound aggregate, pull and with variables", func() {
+			where := q.Pattern("?b", ":book/title", "?title")
+			Ω(q.Find(q.Count("?x")).Where(where).Validate()).Should(test.HaveMessage(q.ErrUnboundVariable))
+			Ω(q.Find(q.Pull("?x", "[*]")).Where(where).Validate()).Should(test.HaveMessage(q.ErrUnboundVariable))
+			Ω(q.Find(q.Count("?b")).With("?x").Where(where).Validate()).Should(test.HaveMessage(q.ErrUnboundVariable))
+			Ω(q.Find(q.Count("?b")).With("x").Where(where).Validate()).Should(test.HaveMessage(q.ErrInvalidQuery))
+		})
+
+		It("should fail on invalid clauses", func() {
+			_, err := q.Find("?b").Where(q.Pattern("?b", ":a", 1), q.Fn("str", "?b")).Element()
+			Ω(err).Should(test.HaveMessage(q.ErrInvalidQuery))
+		})
+	})
+})
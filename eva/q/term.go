@@ -0,0 +1,322 @@
+// Copyright 2018-2019 Workiva Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package q
+
+import (
+	"strings"
+
+	"github.com/Workiva/eva-client-go/edn"
+)
+
+const (
+
+	// VariablePrefix starts every query variable, e.g. ?title.
+	VariablePrefix = "?"
+
+	// SourcePrefix starts every data source, e.g. $ or $books.
+	SourcePrefix = "$"
+
+	// RulesSymbol is the input that binds the rules of the query.
+	RulesSymbol = "%"
+
+	// BlankSymbol matches anything in a pattern without binding it.
+	BlankSymbol = "_"
+
+	// scalarSymbol marks a scalar find spec, e.g. [:find ?title .]
+	scalarSymbol = "."
+
+	// collectionSymbol marks a collection, e.g. [?title ...]
+	collectionSymbol = "..."
+)
+
+// Term is a part of a query that can be converted to an element.
+type Term interface {
+
+	// Element of this term.
+	Element() (edn.Element, error)
+}
+
+// variableTerm is a term that references query variables.
+type variableTerm interface {
+	Term
+
+	// variables referenced by the term.
+	variables() []string
+}
+
+// IsVariable checks if the value is a query variable, e.g. ?title.
+func IsVariable(value string) bool {
+	return len(value) > len(VariablePrefix) && strings.HasPrefix(value, VariablePrefix)
+}
+
+// Sym is a symbol, which is how functions, predicates and rules are referenced in a query.
+type Sym string
+
+// Element of this symbol.
+func (sym Sym) Element() (edn.Element, error) {
+	return edn.NewSymbolElement(string(sym))
+}
+
+// toElement converts the value into a query element. Strings starting with ?, $ or equal to % or _ are symbols,
+// strings starting with : are keywords and all other strings are string literals. Terms and elements are used as is
+// and all other values are converted with edn.MarshalElement.
+func toElement(value interface{}) (elem edn.Element, err error) {
+
+	switch v := value.(type) {
+	case edn.Element:
+		elem = v
+	case Term:
+		elem, err = v.Element()
+	case string:
+		switch {
+		case v == RulesSymbol || v == BlankSymbol || strings.HasPrefix(v, VariablePrefix) || strings.HasPrefix(v, SourcePrefix):
+			elem, err = edn.NewSymbolElement(v)
+		case strings.HasPrefix(v, edn.KeywordPrefix):
+			elem, err = edn.NewKeywordElement(v)
+		default:
+			elem = edn.NewStringElement(v)
+		}
+	default:
+		elem, err = edn.MarshalElement(v)
+	}
+
+	return elem, err
+}
+
+// toElements converts all the values into query elements.
+func toElements(values ...interface{}) (elems []edn.Element, err error) {
+
+	for _, value := range values {
+		var elem edn.Element
+		if elem, err = toElement(value); err != nil {
+			break
+		}
+		elems = append(elems, elem)
+	}
+
+	return elems, err
+}
+
+// variablesOf returns the query variables referenced by the values.
+func variablesOf(values ...interface{}) (vars []string) {
+
+	for _, value := range values {
+		switch v := value.(type) {
+		case string:
+			if IsVariable(v) {
+				vars = append(vars, v)
+			}
+		case variableTerm:
+			vars = append(vars, v.variables()...)
+		}
+	}
+
+	return vars
+}
+
+// list creates a list headed by the symbol, e.g. (count ?x).
+func list(head string, values ...interface{}) (elem edn.Element, err error) {
+
+	var elems []edn.Element
+	if elems, err = toElements(append([]interface{}{Sym(head)}, values...)...); err == nil {
+		elem, err = edn.NewList(elems...)
+	}
+
+	return elem, err
+}
+
+// vector creates a vector of the values.
+func vector(values ...interface{}) (elem edn.Element, err error) {
+
+	var elems []edn.Element
+	if elems, err = toElements(values...); err == nil {
+		elem, err = edn.NewVector(elems...)
+	}
+
+	return elem, err
+}
+
+// variableStrings converts variable names into values.
+func variableStrings(vars []string) []interface{} {
+
+	values := make([]interface{}, len(vars))
+	for i, v := range vars {
+		values[i] = v
+	}
+
+	return values
+}
+
+// checkVariables checks that all the names are query variables.
+func checkVariables(vars ...string) (err error) {
+
+	for _, v := range vars {
+		if !IsVariable(v) {
+			err = edn.MakeErrorWithFormat(ErrInvalidQuery, "expected a variable, got: %s", v)
+			break
+		}
+	}
+
+	return err
+}
+
+// aggregate is a find element that aggregates, e.g. (count ?x).
+type aggregate struct {
+	name string
+	args []interface{}
+}
+
+// Aggregate creates a find element for the aggregate function, e.g. Aggregate("min", 3, "?x") is (min 3 ?x).
+func Aggregate(name string, args ...interface{}) Term {
+	return &aggregate{name: name, args: args}
+}
+
+// Count creates (count ?x).
+func Count(variable string) Term {
+	return Aggregate("count", variable)
+}
+
+// CountDistinct creates (count-distinct ?x).
+func CountDistinct(variable string) Term {
+	return Aggregate("count-distinct", variable)
+}
+
+// Sum creates (sum ?x).
+func Sum(variable string) Term {
+	return Aggregate("sum", variable)
+}
+
+// Avg creates (avg ?x).
+func Avg(variable string) Term {
+	return Aggregate("avg", variable)
+}
+
+// Min creates (min ?x).
+func Min(variable string) Term {
+	return Aggregate("min", variable)
+}
+
+// Max creates (max ?x).
+func Max(variable string) Term {
+	return Aggregate("max", variable)
+}
+
+// Distinct creates (distinct ?x).
+func Distinct(variable string) Term {
+	return Aggregate("distinct", variable)
+}
+
+// Element of this aggregate.
+func (agg *aggregate) Element() (edn.Element, error) {
+	return list(agg.name, agg.args...)
+}
+
+// variables used by the aggregate.
+func (agg *aggregate) variables() []string {
+	return variablesOf(agg.args...)
+}
+
+// pullExpr is a find element that pulls the entity, e.g. (pull ?e [*]).
+type pullExpr struct {
+	variable string
+	pattern  interface{}
+}
+
+// Pull creates a find element that pulls the pattern for the entity, e.g. Pull("?e", "[*]"). Patterns given as
+// strings are parsed as edn.
+func Pull(variable string, pattern interface{}) Term {
+	return &pullExpr{variable: variable, pattern: pattern}
+}
+
+// Element of this pull expression.
+func (pull *pullExpr) Element() (elem edn.Element, err error) {
+
+	pattern := pull.pattern
+	if str, is := pattern.(string); is {
+		pattern, err = edn.Parse(str)
+	}
+
+	if err == nil {
+		if err = checkVariables(pull.variable); err == nil {
+			elem, err = list("pull", pull.variable, pattern)
+		}
+	}
+
+	return elem, err
+}
+
+// variables used by the pull expression.
+func (pull *pullExpr) variables() []string {
+	return []string{pull.variable}
+}
+
+// bindingShape is the shape of an input or function binding.
+type bindingShape int
+
+const (
+	tupleBinding bindingShape = iota
+	collectionBinding
+	relationBinding
+)
+
+// binding binds the variables to a tuple, collection or relation.
+type binding struct {
+	shape bindingShape
+	vars  []string
+}
+
+// BindColl binds the variable to each item of a collection, [?x ...]
+func BindColl(variable string) Term {
+	return &binding{shape: collectionBinding, vars: []string{variable}}
+}
+
+// BindTuple binds the variables to the items of a tuple, [?a ?b]
+func BindTuple(vars ...string) Term {
+	return &binding{shape: tupleBinding, vars: vars}
+}
+
+// BindRel binds the variables to each tuple of a relation, [[?a ?b]]
+func BindRel(vars ...string) Term {
+	return &binding{shape: relationBinding, vars: vars}
+}
+
+// Element of this binding.
+func (bind *binding) Element() (elem edn.Element, err error) {
+
+	if len(bind.vars) == 0 {
+		err = edn.MakeError(ErrInvalidQuery, "empty binding")
+	} else {
+		err = checkVariables(bind.vars...)
+	}
+
+	if err == nil {
+		values := variableStrings(bind.vars)
+		switch bind.shape {
+		case collectionBinding:
+			elem, err = vector(values[0], Sym(collectionSymbol))
+		case relationBinding:
+			elem, err = vector(&binding{shape: tupleBinding, vars: bind.vars})
+		default:
+			elem, err = vector(values...)
+		}
+	}
+
+	return elem, err
+}
+
+// variables bound by the binding.
+func (bind *binding) variables() []string {
+	return bind.vars
+}
@@ -0,0 +1,115 @@
+// Copyright 2018-2019 Workiva Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package q_test
+
+import (
+	"time"
+
+	"github.com/Workiva/eva-client-go/edn"
+	"github.com/Workiva/eva-client-go/eva/q"
+	"github.com/Workiva/eva-client-go/test"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Query terms", func() {
+
+	serialized := func(term q.Term) string {
+		elem, err := term.Element()
+		Ω(err).Should(BeNil())
+
+		out, err := elem.Serialize(edn.EvaEdnMimeType)
+		Ω(err).Should(BeNil())
+		return out
+	}
+
+	It("should recognise variables", func() {
+		Ω(q.IsVariable("?x")).Should(BeTrue())
+		Ω(q.IsVariable("?")).Should(BeFalse())
+		Ω(q.IsVariable("x")).Should(BeFalse())
+		Ω(q.IsVariable("$")).Should(BeFalse())
+	})
+
+	It("should convert the values to elements", func() {
+		when := time.Date(2019, 1, 2, 3, 4, 5, 0, time.UTC)
+		for value, expected := range map[interface{}]string{
+			"?x":                  "?x",
+			"$":                   "$",
+			"$books":              "$books",
+			"%":                   "%",
+			"_":                   "_",
+			":book/title":         ":book/title",
+			"Dune":                `"Dune"`,
+			42:                    "42",
+			true:                  "true",
+			when:                  `#inst "2019-01-02T03:04:05Z"`,
+			q.Sym("str"):          "str",
+			q.Sym("..."):          "...",
+			q.Count("?x"):         "(count ?x)",
+			q.BindColl("?x"):      "[?x ...]",
+			q.CountDistinct(`?x`): "(count-distinct ?x)",
+		} {
+			Ω(serialized(q.Pattern(value))).Should(Equal("[" + expected + "]"))
+		}
+
+		Ω(serialized(q.Pattern(edn.NewIntegerElement(7)))).Should(Equal("[7]"))
+	})
+
+	It("should fail on invalid values", func() {
+		_, err := q.Pattern(":").Element()
+		Ω(err).ShouldNot(BeNil())
+
+		_, err = q.Pattern(q.Sym("1bad")).Element()
+		Ω(err).Should(test.HaveMessage(edn.ErrInvalidSymbol))
+	})
+
+	It("should create the aggregates", func() {
+		Ω(serialized(q.Sum("?x"))).Should(Equal("(sum ?x)"))
+		Ω(serialized(q.Avg("?x"))).Should(Equal("(avg ?x)"))
+		Ω(serialized(q.Min("?x"))).Should(Equal("(min ?x)"))
+		Ω(serialized(q.Max("?x"))).Should(Equal("(max ?x)"))
+		Ω(serialized(q.Distinct("?x"))).Should(Equal("(distinct ?x)"))
+		Ω(serialized(q.Aggregate("min", 3, "?x"))).Should(Equal("(min 3 ?x)"))
+		Ω(q.Find(q.Aggregate("min", 3, "?x")).In("?x").Validate()).Should(Succeed())
+		Ω(q.Find(q.Aggregate("min", 3, "?x")).Validate()).Should(test.HaveMessage(q.ErrUnboundVariable))
+	})
+
+	It("should create the pull expressions", func() {
+		Ω(serialized(q.Pull("?e", "[:book/title {:book/author [*]}]"))).Should(Equal("(pull ?e [:book/title {:book/author [*]}])"))
+
+		pattern, err := edn.Parse("[*]")
+		Ω(err).Should(BeNil())
+		Ω(serialized(q.Pull("?e", pattern))).Should(Equal("(pull ?e [*])"))
+
+		_, err = q.Pull("e", "[*]").Element()
+		Ω(err).Should(test.HaveMessage(q.ErrInvalidQuery))
+
+		_, err = q.Pull("?e", "[*").Element()
+		Ω(err).ShouldNot(BeNil())
+	})
+
+	It("should create the bindings", func() {
+		Ω(serialized(q.BindColl("?x"))).Should(Equal("[?x ...]"))
+		Ω(serialized(q.BindTuple("?a", "?b"))).Should(Equal("[?a ?b]"))
+		Ω(serialized(q.BindRel("?a", "?b"))).Should(Equal("[[?a ?b]]"))
+		Ω(q.Find("?a", "?b").In(q.BindRel("?a", "?b")).Validate()).Should(Succeed())
+
+		_, err := q.BindTuple().Element()
+		Ω(err).Should(test.HaveMessage(q.ErrInvalidQuery))
+
+		_, err = q.BindColl("x").Element()
+		Ω(err).Should(test.HaveMessage(q.ErrInvalidQuery))
+	})
+})
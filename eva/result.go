@@ -14,6 +14,10 @@
 
 package eva
 
+import (
+	"github.com/Workiva/eva-client-go/edn"
+)
+
 // Result of the call.
 type Result interface {
 
@@ -22,4 +26,20 @@ type Result interface {
 
 	// Error from the call.
 	Error() (error, bool)
+
+	// Element parses the body of the call, the element is parsed once and cached.
+	Element() (edn.Element, error)
+
+	// Decode the body of the call into the value pointed to by v, see edn.UnmarshalElement for the conversion rules.
+	// If the call failed, the error from the call is returned instead.
+	Decode(v interface{}) error
+
+	// StatusCode of the call.
+	StatusCode() int
+
+	// ContentType of the body of the call.
+	ContentType() string
+
+	// Headers returned with the call.
+	Headers() map[string][]string
 }
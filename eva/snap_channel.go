@@ -15,6 +15,8 @@
 package eva
 
 import (
+	"context"
+
 	"github.com/Workiva/eva-client-go/edn"
 )
 
@@ -28,17 +30,23 @@ type SnapshotChannel interface {
 	Channel
 
 	// Pull from the snapshot.
-	Pull(pattern interface{}, ids interface{}, parameters ...interface{}) (Result, error)
+	Pull(pattern interface{}, ids interface{}, parameters ...interface{}) (*PullResult, error)
+
+	// PullContext pulls from the snapshot, the call is abandoned when the context is done.
+	PullContext(ctx context.Context, pattern interface{}, ids interface{}, parameters ...interface{}) (*PullResult, error)
 
 	// Invoke from the snapshot
 	Invoke(function interface{}, parameters ...interface{}) (Result, error)
 
+	// InvokeContext invokes from the snapshot, the call is abandoned when the context is done.
+	InvokeContext(ctx context.Context, function interface{}, parameters ...interface{}) (Result, error)
+
 	// AsOf the time specified.
 	AsOf() *int
 }
 
-type PullImplementation func(pattern edn.Serializable, ids edn.Serializable, params ...interface{}) (result Result, err error)
-type InvokeImplementation func(function edn.Serializable, parameters ...interface{}) (result Result, err error)
+type PullImplementation func(ctx context.Context, pattern edn.Serializable, ids edn.Serializable, params ...interface{}) (result Result, err error)
+type InvokeImplementation func(ctx context.Context, function edn.Serializable, parameters ...interface{}) (result Result, err error)
 
 type BaseSnapshotChannel struct {
 	*BaseChannel
@@ -93,7 +101,12 @@ func (channel *BaseSnapshotChannel) AsOf() (asOf *int) {
 }
 
 // Pull from the snapshot.
-func (channel *BaseSnapshotChannel) Pull(pattern interface{}, ids interface{}, parameters ...interface{}) (result Result, err error) {
+func (channel *BaseSnapshotChannel) Pull(pattern interface{}, ids interface{}, parameters ...interface{}) (result *PullResult, err error) {
+	return channel.PullContext(context.Background(), pattern, ids, parameters...)
+}
+
+// PullContext pulls from the snapshot, the call is abandoned when the context is done.
+func (channel *BaseSnapshotChannel) PullContext(ctx context.Context, pattern interface{}, ids interface{}, parameters ...interface{}) (result *PullResult, err error) {
 
 	var ptrn edn.Serializable
 	var idSer edn.Serializable
@@ -103,7 +116,10 @@ func (channel *BaseSnapshotChannel) Pull(pattern interface{}, ids interface{}, p
 	}
 
 	if err == nil {
-		result, err = channel.pullImpl(ptrn, idSer, parameters...)
+		var raw Result
+		if raw, err = channel.pullImpl(ctx, ptrn, idSer, parameters...); err == nil {
+			result = NewPullResult(raw)
+		}
 	}
 
 	return result, err
@@ -111,12 +127,17 @@ func (channel *BaseSnapshotChannel) Pull(pattern interface{}, ids interface{}, p
 
 // Invoke from the snapshot
 func (channel *BaseSnapshotChannel) Invoke(function interface{}, parameters ...interface{}) (result Result, err error) {
+	return channel.InvokeContext(context.Background(), function, parameters...)
+}
+
+// InvokeContext invokes from the snapshot, the call is abandoned when the context is done.
+func (channel *BaseSnapshotChannel) InvokeContext(ctx context.Context, function interface{}, parameters ...interface{}) (result Result, err error) {
 
 	var funcElem edn.Serializable
 	funcElem, err = decodeSerializable(function)
 
 	if err == nil {
-		result, err = channel.invokeImpl(funcElem, parameters...)
+		result, err = channel.invokeImpl(ctx, funcElem, parameters...)
 	}
 
 	return result, err
@@ -40,7 +40,7 @@ var _ = Describe("snapshot channel test", func() {
 			Ω(snap).ShouldNot(BeNil())
 			Ω(snap.Label()).Should(BeEquivalentTo("label"))
 
-			var result Result
+			var result *PullResult
 			result, err = snap.Pull("[*]", "123")
 			Ω(err).Should(BeNil())
 
@@ -15,6 +15,8 @@
 package eva
 
 import (
+	"context"
+
 	"github.com/Workiva/eva-client-go/edn"
 )
 
@@ -47,6 +49,9 @@ type Source interface {
 
 	// Query the source for data.
 	Query(query interface{}, parameters ...interface{}) (Result, error)
+
+	// QueryContext queries the source for data, the call is abandoned when the context is done.
+	QueryContext(ctx context.Context, query interface{}, parameters ...interface{}) (Result, error)
 }
 
 // sourceFactory defines the mechanism for creating a source.
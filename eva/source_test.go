@@ -15,6 +15,8 @@
 package eva
 
 import (
+	"context"
+
 	"github.com/Workiva/eva-client-go/edn"
 	"github.com/Workiva/eva-client-go/test"
 	. "github.com/onsi/ginkgo"
@@ -162,7 +164,7 @@ var _ = Describe("Source test", func() {
 		clean()
 	})
 
-	queryImpl := func(interface{}, ...interface{}) (Result, error) {
+	queryImpl := func(context.Context, interface{}, ...interface{}) (Result, error) {
 		return nil, nil
 	}
 
@@ -186,6 +188,34 @@ var _ = Describe("Source test", func() {
 			Ω(err).Should(BeNil())
 		})
 
+		It("should pass the context to the query implementation", func() {
+			config, err := NewConfiguration("{\"category\": \"foo\"}")
+			Ω(err).Should(BeNil())
+
+			tenant, err := NewTenant("foo")
+			Ω(err).Should(BeNil())
+
+			type ctxKey string
+			ctx := context.WithValue(context.Background(), ctxKey("key"), "value")
+
+			var got context.Context
+			source, err := NewBaseSource(config, tenant, &mockSource{}, func(label edn.Serializable, source Source) (c ConnectionChannel, e error) {
+				return c, e
+			}, func(c context.Context, _ interface{}, _ ...interface{}) (Result, error) {
+				got = c
+				return nil, nil
+			})
+			Ω(err).Should(BeNil())
+
+			_, err = source.QueryContext(ctx, nil)
+			Ω(err).Should(BeNil())
+			Ω(got).Should(BeIdenticalTo(ctx))
+
+			_, err = source.Query(nil)
+			Ω(err).Should(BeNil())
+			Ω(got).Should(BeIdenticalTo(context.Background()))
+		})
+
 		It("", func() {
 			source, err := NewBaseSource(nil, nil, &mockSource{}, func(label edn.Serializable, source Source) (c ConnectionChannel, e error) {
 				return c, e
@@ -0,0 +1,317 @@
+// Copyright 2018-2019 Workiva Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eva
+
+import (
+	"github.com/Workiva/eva-client-go/edn"
+)
+
+const (
+
+	// ErrInvalidTxReport defines the error for a transaction result that could not be read.
+	ErrInvalidTxReport = edn.ErrorMessage("Invalid transaction report")
+)
+
+const (
+	txTempIdsKey      = "tempids"
+	txServicePrefix   = "eva.client.service"
+	txDbBeforeKey     = "db-before"
+	txDbAfterKey      = "db-after"
+	txDataKey         = "tx-data"
+	txDatomTag        = "datom"
+	txTempIdTag       = "db/id"
+	datomElementCount = 5
+)
+
+func init() {
+	PanicOnError(func() (err error) {
+		if labelKeyword, err = edn.NewKeywordElement(LabelReferenceProperty); err == nil {
+			asOfKeyword, err = edn.NewKeywordElement(AsOfReferenceProperty)
+		}
+		return err
+	})
+}
+
+var labelKeyword edn.SymbolElement
+var asOfKeyword edn.SymbolElement
+
+// Datom is a single fact asserted or retracted by a transaction.
+type Datom struct {
+	Entity    int64
+	Attribute int64
+	Value     edn.Element
+	Tx        int64
+	Added     bool
+}
+
+// SnapshotRef identifies the snapshot of a connection before or after a transaction.
+type SnapshotRef struct {
+	Label  string
+	BasisT int64
+}
+
+// TxReport is the result of a transaction. The report is filled from the result of the call, if the call failed only
+// the result is available and the error can be found with Error().
+type TxReport struct {
+	Result
+
+	// TempIDs maps the plain temp ids, e.g. -1, to the resolved entity ids.
+	TempIDs map[int64]int64
+
+	// PartitionedTempIDs maps the partition, e.g. :db.part/user, and the temp id of #db/id [:db.part/user -1] to
+	// the resolved entity ids.
+	PartitionedTempIDs map[string]map[int64]int64
+
+	// DbBefore is the snapshot before the transaction.
+	DbBefore SnapshotRef
+
+	// DbAfter is the snapshot after the transaction.
+	DbAfter SnapshotRef
+
+	// TxData holds the datoms of the transaction.
+	TxData []Datom
+}
+
+// NewTxReport reads the transaction report from the result of a transaction. The transaction has already been
+// committed when the report is read, so a report that can not be fully read is still returned with its result and
+// the parts that could be read, along with the ErrInvalidTxReport error.
+func NewTxReport(result Result) (report *TxReport, err error) {
+
+	if result != nil {
+		report = &TxReport{
+			Result:             result,
+			TempIDs:            map[int64]int64{},
+			PartitionedTempIDs: map[string]map[int64]int64{},
+		}
+
+		if _, failed := result.Error(); !failed {
+			var elem edn.Element
+			if elem, err = result.Element(); err == nil {
+				err = report.read(elem)
+			}
+
+			if err != nil {
+				err = edn.MakeError(ErrInvalidTxReport, err)
+			}
+		}
+	}
+
+	return report, err
+}
+
+// ResolveTempID returns the entity id the temp id was resolved to. The temp id can be a plain integer, a #db/id
+// element or the edn string of either, e.g. "#db/id [:db.part/user -1]".
+func (report *TxReport) ResolveTempID(tempId interface{}) (id int64, has bool) {
+
+	switch v := tempId.(type) {
+	case int:
+		id, has = report.TempIDs[int64(v)]
+	case int64:
+		id, has = report.TempIDs[v]
+	case string:
+		if elem, err := edn.Parse(v); err == nil {
+			id, has = report.ResolveTempID(elem)
+		}
+	case edn.Element:
+		if v.Tag() == txTempIdTag {
+			if partition, tempId, err := readTempId(v); err == nil {
+				id, has = report.PartitionedTempIDs[partition][tempId]
+			}
+		} else if tempId, err := edn.AsInt(v); err == nil {
+			id, has = report.TempIDs[tempId]
+		}
+	}
+
+	return id, has
+}
+
+// read the report from the parsed result. Each part is read even if an earlier one fails, the first failure is
+// returned.
+func (report *TxReport) read(elem edn.Element) (err error) {
+
+	var coll edn.CollectionElement
+	if coll, err = asMap(elem); err == nil {
+		_ = coll.IterateChildren(func(key edn.Element, value edn.Element) error {
+
+			var e error
+			var keyword edn.SymbolElement
+			if keyword, e = edn.AsKeyword(key); e == nil {
+				switch keyword.Prefix() {
+				case "":
+					switch keyword.Name() {
+					case txTempIdsKey:
+						e = report.readTempIds(value)
+					case txDbBeforeKey:
+						report.DbBefore, e = readSnapshotRef(value)
+					case txDbAfterKey:
+						report.DbAfter, e = readSnapshotRef(value)
+					case txDataKey:
+						report.TxData, e = readDatoms(value)
+					}
+				case txServicePrefix:
+					if keyword.Name() == txTempIdsKey {
+						e = report.readPartitionedTempIds(value)
+					}
+				}
+			}
+
+			if err == nil {
+				err = e
+			}
+			return nil
+		})
+	}
+
+	return err
+}
+
+// asMap returns the element as a map collection.
+func asMap(elem edn.Element) (coll edn.CollectionElement, err error) {
+	if coll, err = edn.AsCollection(elem); err == nil && elem.ElementType() != edn.MapType {
+		coll, err = nil, edn.MakeErrorWithFormat(edn.ErrUnexpectedType, "expected a map, got: %s", elem.String())
+	}
+	return coll, err
+}
+
+// readTempIds reads the plain temp ids.
+func (report *TxReport) readTempIds(elem edn.Element) (err error) {
+
+	var coll edn.CollectionElement
+	if coll, err = asMap(elem); err == nil {
+		err = coll.IterateChildren(func(key edn.Element, value edn.Element) (e error) {
+			var tempId, id int64
+			if tempId, e = edn.AsInt(key); e == nil {
+				if id, e = edn.AsInt(value); e == nil {
+					report.TempIDs[tempId] = id
+				}
+			}
+			return e
+		})
+	}
+
+	return err
+}
+
+// readPartitionedTempIds reads the temp ids keyed by #db/id [partition id].
+func (report *TxReport) readPartitionedTempIds(elem edn.Element) (err error) {
+
+	var coll edn.CollectionElement
+	if coll, err = asMap(elem); err == nil {
+		err = coll.IterateChildren(func(key edn.Element, value edn.Element) (e error) {
+			var partition string
+			var tempId, id int64
+			if partition, tempId, e = readTempId(key); e == nil {
+				if id, e = edn.AsInt(value); e == nil {
+					if _, has := report.PartitionedTempIDs[partition]; !has {
+						report.PartitionedTempIDs[partition] = map[int64]int64{}
+					}
+					report.PartitionedTempIDs[partition][tempId] = id
+				}
+			}
+			return e
+		})
+	}
+
+	return err
+}
+
+// readTempId reads the partition and id of a #db/id [partition id] element.
+func readTempId(elem edn.Element) (partition string, tempId int64, err error) {
+
+	var coll edn.CollectionElement
+	if coll, err = edn.AsCollection(elem); err == nil {
+		if coll.Len() == 2 {
+			var part, id edn.Element
+			if part, err = coll.Get(0); err == nil {
+				if id, err = coll.Get(1); err == nil {
+					partition = part.String()
+					tempId, err = edn.AsInt(id)
+				}
+			}
+		} else {
+			err = edn.MakeErrorWithFormat(edn.ErrUnexpectedType, "expected [partition id], got: %s", elem.String())
+		}
+	}
+
+	return partition, tempId, err
+}
+
+// readSnapshotRef reads the label and basis-t of a snapshot reference.
+func readSnapshotRef(elem edn.Element) (ref SnapshotRef, err error) {
+
+	var coll edn.CollectionElement
+	if coll, err = asMap(elem); err == nil {
+		var label, asOf edn.Element
+		if label, err = coll.Get(labelKeyword); err == nil {
+			if ref.Label, err = edn.AsString(label); err != nil {
+				ref.Label, err = label.String(), nil
+			}
+		}
+
+		if err == nil {
+			if asOf, err = coll.Get(asOfKeyword); err == nil {
+				ref.BasisT, err = edn.AsInt(asOf)
+			}
+		}
+	}
+
+	return ref, err
+}
+
+// readDatoms reads the #datom [e a v t added] elements of the transaction data.
+func readDatoms(elem edn.Element) (datoms []Datom, err error) {
+
+	var coll edn.CollectionElement
+	if coll, err = edn.AsCollection(elem); err == nil {
+		err = coll.IterateChildren(func(_ edn.Element, value edn.Element) (e error) {
+
+			var datom edn.CollectionElement
+			if datom, e = edn.AsCollection(value); e == nil {
+				if value.Tag() != txDatomTag || datom.Len() != datomElementCount {
+					e = edn.MakeErrorWithFormat(edn.ErrUnexpectedType, "expected #datom [e a v t added], got: %s", value.String())
+				}
+			}
+
+			if e == nil {
+				d := Datom{}
+				var part edn.Element
+				for i := 0; i < datomElementCount && e == nil; i++ {
+					if part, e = datom.Get(i); e == nil {
+						switch i {
+						case 0:
+							d.Entity, e = edn.AsInt(part)
+						case 1:
+							d.Attribute, e = edn.AsInt(part)
+						case 2:
+							d.Value = part
+						case 3:
+							d.Tx, e = edn.AsInt(part)
+						case 4:
+							d.Added, e = edn.AsBool(part)
+						}
+					}
+				}
+
+				if e == nil {
+					datoms = append(datoms, d)
+				}
+			}
+
+			return e
+		})
+	}
+
+	return datoms, err
+}
@@ -0,0 +1,149 @@
+// Copyright 2018-2019 Workiva Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eva
+
+import (
+	"github.com/Workiva/eva-client-go/edn"
+	"github.com/Workiva/eva-client-go/test"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Transaction report", func() {
+
+	Context("with a valid report", func() {
+
+		var report *TxReport
+
+		BeforeEach(func() {
+			var err error
+			report, err = NewTxReport(&mockResult{body: mockTxReport})
+			Ω(err).Should(BeNil())
+			Ω(report).ShouldNot(BeNil())
+		})
+
+		It("should read the temp ids", func() {
+			Ω(report.TempIDs).Should(Equal(map[int64]int64{-1: 4398046511105}))
+			Ω(report.PartitionedTempIDs).Should(Equal(map[string]map[int64]int64{
+				":db.part/user": {-1: 8796093023233},
+			}))
+		})
+
+		It("should read the snapshots", func() {
+			Ω(report.DbBefore).Should(Equal(SnapshotRef{Label: "label", BasisT: 1}))
+			Ω(report.DbAfter).Should(Equal(SnapshotRef{Label: "label", BasisT: 2}))
+		})
+
+		It("should read the datoms", func() {
+			Ω(report.TxData).Should(HaveLen(1))
+
+			datom := report.TxData[0]
+			Ω(datom.Entity).Should(BeEquivalentTo(4398046511105))
+			Ω(datom.Attribute).Should(BeEquivalentTo(3))
+			Ω(datom.Value.Value()).Should(Equal("value"))
+			Ω(datom.Tx).Should(BeEquivalentTo(4398046511106))
+			Ω(datom.Added).Should(BeTrue())
+		})
+
+		It("should keep the result", func() {
+			str, has := report.String()
+			Ω(has).Should(BeTrue())
+			Ω(str).Should(Equal(mockTxReport))
+		})
+
+		It("should resolve the temp ids", func() {
+			tempId, err := edn.Parse("#db/id [:db.part/user -1]")
+			Ω(err).Should(BeNil())
+
+			for _, id := range []interface{}{-1, int64(-1), "-1", edn.NewIntegerElement(-1)} {
+				resolved, has := report.ResolveTempID(id)
+				Ω(has).Should(BeTrue())
+				Ω(resolved).Should(BeEquivalentTo(4398046511105))
+			}
+
+			for _, id := range []interface{}{tempId, "#db/id [:db.part/user -1]"} {
+				resolved, has := report.ResolveTempID(id)
+				Ω(has).Should(BeTrue())
+				Ω(resolved).Should(BeEquivalentTo(8796093023233))
+			}
+
+			for _, id := range []interface{}{-2, "#db/id [:db.part/tx -1]", "#db/id [-1]", "[", 1.5, nil} {
+				_, has := report.ResolveTempID(id)
+				Ω(has).Should(BeFalse())
+			}
+		})
+	})
+
+	It("should handle a nil result", func() {
+		report, err := NewTxReport(nil)
+		Ω(err).Should(BeNil())
+		Ω(report).Should(BeNil())
+	})
+
+	It("should not read a failed result", func() {
+		failure := edn.MakeError(ErrSourceError, "failed")
+		report, err := NewTxReport(&mockResult{body: `{:ex-info {:code 3000}}`, failure: failure})
+		Ω(err).Should(BeNil())
+		Ω(report).ShouldNot(BeNil())
+		Ω(report.TempIDs).Should(BeEmpty())
+
+		resErr, has := report.Error()
+		Ω(has).Should(BeTrue())
+		Ω(resErr).Should(Equal(failure))
+	})
+
+	It("should ignore unknown keys", func() {
+		report, err := NewTxReport(&mockResult{body: `{:tempids {} :future/key 1 :eva.client.service/other 2}`})
+		Ω(err).Should(BeNil())
+		Ω(report.TempIDs).Should(BeEmpty())
+		Ω(report.TxData).Should(BeEmpty())
+	})
+
+	It("should report invalid reports", func() {
+		for _, body := range []string{
+			`[]`,
+			`{"tempids" {}}`,
+			`{:tempids [1 2]}`,
+			`{:tempids {-1 "one"}}`,
+			`{:eva.client.service/tempids {-1 2}}`,
+			`{:eva.client.service/tempids {#db/id [:db.part/user] 2}}`,
+			`{:db-before {:label "label"}}`,
+			`{:db-after {:label "label" :as-of "one"}}`,
+			`{:tx-data ([1 2 3 4 true])}`,
+			`{:tx-data (#datom [1 2 3 4])}`,
+			`{:tx-data (#datom [1 2 3 4 "true"])}`,
+			`{:tx-data 1}`,
+		} {
+			result := &mockResult{body: body}
+			report, err := NewTxReport(result)
+			Ω(err).Should(test.HaveMessage(ErrInvalidTxReport), body)
+			Ω(report).ShouldNot(BeNil(), body)
+			Ω(report.Result).Should(Equal(result))
+		}
+	})
+
+	It("should keep the parts that can be read", func() {
+		report, err := NewTxReport(&mockResult{body: `{
+			:tempids {-1 2}
+			:db-before {:label "before"}
+			:db-after {:label "after" :as-of 5}
+			:tx-data (#datom [1 :x 3 4 true])}`})
+		Ω(err).Should(test.HaveMessage(ErrInvalidTxReport))
+		Ω(report.TempIDs).Should(Equal(map[int64]int64{-1: 2}))
+		Ω(report.DbBefore.Label).Should(Equal("before"))
+		Ω(report.DbAfter).Should(Equal(SnapshotRef{Label: "after", BasisT: 5}))
+		Ω(report.TxData).Should(BeEmpty())
+	})
+})
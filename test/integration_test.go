@@ -62,7 +62,7 @@ func newTester(server, port, category, label, tenant string) *httpTester {
 	}
 }
 
-func (tester *httpTester) transact(format string, args ...interface{}) (r *transactResult) {
+func (tester *httpTester) transact(format string, args ...interface{}) *transactResult {
 	trx := fmt.Sprintf(format, args...)
 
 	var conn eva.ConnectionChannel
@@ -70,166 +70,19 @@ func (tester *httpTester) transact(format string, args ...interface{}) (r *trans
 	Ω(err).Should(BeNil())
 	Ω(conn).ShouldNot(BeNil())
 
-	result, err := conn.Transact(trx)
+	report, err := conn.Transact(trx)
 	Ω(err).Should(BeNil())
-	Ω(result).ShouldNot(BeNil())
+	Ω(report).ShouldNot(BeNil())
 
-	v, h := result.String()
+	v, h := report.String()
 	Ω(v).ShouldNot(HaveLen(0))
 	Ω(h).Should(BeTrue())
 
-	var elem edn.Element
-	if elem, err = edn.Parse(v); err == nil {
-
-		r = &transactResult{
-			rawResult:          v,
-			partitionedTempIds: make(map[string]map[int64]int64),
-			rawTempIds:         make(map[int64]int64),
-		}
-
-		if collElem, is := elem.(edn.CollectionElement); is {
-			err = collElem.IterateChildren(func(key edn.Element, value edn.Element) (e error) {
-
-				if key.ElementType() == edn.KeywordType {
-					switch keyElem := key.(edn.SymbolElement); keyElem.Name() {
-					case "tempids":
-						if keyElem.Prefix() == "eva.client.service" {
-							e = value.(edn.CollectionElement).IterateChildren(func(tempIdKey edn.Element, tempIdValue edn.Element) (e2 error) {
-								if tempIdKey.Tag() == "db/id" && tempIdKey.ElementType() == edn.VectorType && tempIdValue.ElementType() == edn.IntegerType {
-
-									keyColl := tempIdKey.(edn.CollectionElement)
-
-									var part edn.Element
-									if part, e2 = keyColl.Get(0); e2 == nil {
-										p := part.String()
-										var id edn.Element
-										if id, e2 = keyColl.Get(1); e2 == nil {
-
-											if _, has := r.partitionedTempIds[p]; !has {
-												r.partitionedTempIds[p] = make(map[int64]int64)
-											}
-											r.partitionedTempIds[p][id.Value().(int64)] = tempIdValue.Value().(int64)
-										}
-									}
-								} else {
-									e2 = fmt.Errorf("unexpected `eva.client.service/tempids` key or value: `%+v` : `%+v`", tempIdKey, tempIdValue)
-								}
-								return e2
-							})
-						} else {
-							if value.ElementType() == edn.MapType {
-								e = value.(edn.CollectionElement).IterateChildren(func(tempIdKey edn.Element, tempIdValue edn.Element) (e2 error) {
-									if tempIdKey.ElementType() == edn.IntegerType && tempIdValue.ElementType() == edn.IntegerType {
-										r.rawTempIds[tempIdKey.Value().(int64)] = tempIdValue.Value().(int64)
-									} else {
-										e2 = fmt.Errorf("unexpected `tempids` key or value: `%+v` : `%+v`", tempIdKey, tempIdValue)
-									}
-									return e2
-								})
-							} else {
-								e = fmt.Errorf("unexpected `tempids` to be a map")
-							}
-						}
-					case "db-before":
-						if value.Tag() == "eva.client.service/snapshot-ref" && value.ElementType() == edn.MapType {
-							e = value.(edn.CollectionElement).IterateChildren(func(k edn.Element, v edn.Element) (e2 error) {
-
-								if key.ElementType() == edn.KeywordType {
-									switch ke := k.(edn.SymbolElement); ke.Name() {
-									case "label":
-										r.beforeLabel = v.String()
-									case "as-of":
-										r.beforeT = v.Value().(int64)
-									default:
-										e2 = fmt.Errorf("unexpected keys in the `db-before` map, got: %s", k.String())
-									}
-								} else {
-									e2 = fmt.Errorf("expected only keyword keys in the `db-before` map, got: %s", k.String())
-								}
-								return e2
-							})
-						} else {
-							e = fmt.Errorf("unexpected `db-before` to be a map with the `eva.client.service/snapshot-ref` tag")
-						}
-					case "db-after":
-						if value.Tag() == "eva.client.service/snapshot-ref" && value.ElementType() == edn.MapType {
-							e = value.(edn.CollectionElement).IterateChildren(func(k edn.Element, v edn.Element) (e2 error) {
-
-								if key.ElementType() == edn.KeywordType {
-									switch ke := k.(edn.SymbolElement); ke.Name() {
-									case "label":
-										r.afterLabel = v.String()
-									case "as-of":
-										r.afterT = v.Value().(int64)
-									default:
-										e2 = fmt.Errorf("unexpected keys in the `db-after` map, got: %s", k.String())
-									}
-								} else {
-									e2 = fmt.Errorf("expected only keyword keys in the `db-after` map, got: %s", k.String())
-								}
-								return e2
-							})
-						} else {
-							e = fmt.Errorf("unexpected `db-before` to be a map with the `eva.client.service/snapshot-ref` tag")
-						}
-					case "tx-data":
-						if value.ElementType() == edn.ListType {
-							e = value.(edn.CollectionElement).IterateChildren(func(_ edn.Element, v edn.Element) (e2 error) {
-
-								if v.Tag() == "datom" && v.ElementType() == edn.VectorType {
-
-									vAsColl := v.(edn.CollectionElement)
-
-									var datomE edn.Element
-									var datomA edn.Element
-									var datomV edn.Element
-									var datomT edn.Element
-									var datomR edn.Element
-
-									if datomE, e2 = vAsColl.Get(0); e2 == nil {
-										if datomA, e2 = vAsColl.Get(1); e2 == nil {
-											if datomV, e2 = vAsColl.Get(2); e2 == nil {
-												if datomT, e2 = vAsColl.Get(3); e2 == nil {
-													if datomR, e2 = vAsColl.Get(4); e2 == nil {
-														r.datoms = append(r.datoms, &datom{
-															e: datomE.Value().(int64),
-															a: datomA.Value().(int64),
-															v: datomV,
-															t: datomT.Value().(int64),
-															r: datomR.Value().(bool),
-														})
-													}
-												}
-											}
-										}
-									}
-
-								} else {
-									e2 = fmt.Errorf("expected vectors with `datom` tags in `tx-data`, Got: %s", v.String())
-								}
-
-								return e2
-							})
-						} else {
-							e = fmt.Errorf("unexpected `tx-data` to be a list")
-						}
-					default:
-						e = fmt.Errorf("unexpected key value: %s", key.String())
-					}
-				} else {
-					e = fmt.Errorf("expected only keyword keys in the map, got: %s", key.String())
-				}
-
-				return e
-			})
-		} else {
-			err = fmt.Errorf("expected a collection, got: %s", v)
-		}
-	}
-
+	err, h = report.Error()
 	Ω(err).Should(BeNil())
-	Ω(r).ShouldNot(BeNil())
-	return r
+	Ω(h).Should(BeFalse())
+
+	return &transactResult{TxReport: report}
 }
 
 func (tester *httpTester) query(query string, items ...interface{}) string {
@@ -248,7 +101,7 @@ func (tester *httpTester) pull(pattern string, ids interface{}, items ...interfa
 	Ω(err).Should(BeNil())
 	Ω(snap).ShouldNot(BeNil())
 
-	var result eva.Result
+	var result *eva.PullResult
 	result, err = snap.Pull(pattern, ids, items...)
 	Ω(err).Should(BeNil())
 	Ω(result).ShouldNot(BeNil())
@@ -264,7 +117,7 @@ func (tester *httpTester) pullAt(t interface{}, pattern string, ids interface{},
 	Ω(err).Should(BeNil())
 	Ω(snap).ShouldNot(BeNil())
 
-	var result eva.Result
+	var result *eva.PullResult
 	result, err = snap.Pull(pattern, ids, items...)
 	Ω(err).Should(BeNil())
 	Ω(result).ShouldNot(BeNil())
@@ -275,37 +128,22 @@ func (tester *httpTester) pullAt(t interface{}, pattern string, ids interface{},
 	return v
 }
 
-type datom struct {
-	e int64
-	a int64
-	v edn.Element
-	t int64
-	r bool
-}
-
 type transactResult struct {
-	rawResult          string
-	partitionedTempIds map[string]map[int64]int64
-	rawTempIds         map[int64]int64
-	beforeLabel        string
-	beforeT            int64
-	afterLabel         string
-	afterT             int64
-	datoms             []*datom
+	*eva.TxReport
 }
 
 func (d *transactResult) mayHaveValue(value string) {
 
 	has := false
 	var values []interface{}
-	for _, datom := range d.datoms {
-		if datom.v.ElementType() == edn.StringType {
-			if value == datom.v.Value().(string) {
+	for _, datom := range d.TxData {
+		if datom.Value.ElementType() == edn.StringType {
+			if value == datom.Value.Value().(string) {
 				has = true
 			}
 		}
 
-		values = append(values, datom.v.Value())
+		values = append(values, datom.Value.Value())
 	}
 
 	if !has {
@@ -317,14 +155,14 @@ func (d *transactResult) mustHaveValue(value string) {
 
 	has := false
 	var values []interface{}
-	for _, datom := range d.datoms {
-		if datom.v.ElementType() == edn.StringType {
-			if value == datom.v.Value().(string) {
+	for _, datom := range d.TxData {
+		if datom.Value.ElementType() == edn.StringType {
+			if value == datom.Value.Value().(string) {
 				has = true
 			}
 		}
 
-		values = append(values, datom.v.Value())
+		values = append(values, datom.Value.Value())
 	}
 
 	if !has {
@@ -333,19 +171,16 @@ func (d *transactResult) mustHaveValue(value string) {
 }
 
 func (d *transactResult) resultTemp(partition string, id int64) (out int64) {
-	if coll, has := d.partitionedTempIds[partition]; has {
-		if out, has = coll[id]; !has {
-			Fail(fmt.Sprintf("Expected to have a temp result is [%d] in partition: `%s` but didn't", id, partition))
-		}
-	} else {
-		Fail(fmt.Sprintf("Expected to have a temp result partition: `%s` but didn't", partition))
+	var has bool
+	if out, has = d.ResolveTempID(fmt.Sprintf("#db/id [%s %d]", partition, id)); !has {
+		Fail(fmt.Sprintf("Expected to have a temp result is [%d] in partition: `%s` but didn't", id, partition))
 	}
 
 	return out
 }
 
 func (d *transactResult) dbAfterT() int64 {
-	return d.afterT
+	return d.DbAfter.BasisT
 }
 
 var _ = Describe("General integration tests", func() {